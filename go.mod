@@ -6,8 +6,10 @@ require gopkg.in/yaml.v3 v3.0.1
 
 require github.com/beevik/etree v1.5.1
 
+require golang.org/x/image v0.25.0
+
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/spf13/cobra v1.9.1 
+	github.com/spf13/cobra v1.9.1
 	github.com/spf13/pflag v1.0.6 // indirect
 )
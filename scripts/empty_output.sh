@@ -5,7 +5,7 @@ set -euo pipefail
 
 OUTPUT_DIR="output"
 
-echo "Deleting all .drawio, .drawio.xml, .figma.json, and .txt files in the '$OUTPUT_DIR' directory..."
+echo "Deleting all layouts, previews, exports, the navigation graph, story map, run report and run checkpoint in the '$OUTPUT_DIR' directory..."
 
 if [ ! -d "$OUTPUT_DIR" ]; then
   echo "Directory '$OUTPUT_DIR' does not exist. Nothing to clean."
@@ -13,9 +13,25 @@ if [ ! -d "$OUTPUT_DIR" ]; then
 fi
 
 shopt -s nullglob
-FILES=("$OUTPUT_DIR"/*.drawio "$OUTPUT_DIR"/*.drawio.xml "$OUTPUT_DIR"/*.figma.json "$OUTPUT_DIR"/*.txt)
+FILES=(
+  # Layouts and the run checkpoint
+  "$OUTPUT_DIR"/*.drawio "$OUTPUT_DIR"/*.drawio.xml "$OUTPUT_DIR"/*.figma.json "$OUTPUT_DIR"/*.txt
+  "$OUTPUT_DIR"/.holoplan_state.json "$OUTPUT_DIR"/.holoplan_state.json.tmp
+  # Previews and exports (the HTML prototype, Excalidraw, Salt, Balsamiq)
+  "$OUTPUT_DIR"/*.svg "$OUTPUT_DIR"/*.png "$OUTPUT_DIR"/*.html
+  "$OUTPUT_DIR"/*.excalidraw "$OUTPUT_DIR"/*.puml "$OUTPUT_DIR"/*.bmml
+  # Navigation graph, story map and run report
+  "$OUTPUT_DIR"/navigation.mmd "$OUTPUT_DIR"/stories.canvas "$OUTPUT_DIR"/report.md
+)
 shopt -u nullglob
 
+# nullglob does not drop plain names that are missing
+EXISTING=()
+for f in "${FILES[@]}"; do
+  if [ -e "$f" ]; then EXISTING+=("$f"); fi
+done
+FILES=("${EXISTING[@]+"${EXISTING[@]}"}")
+
 if [ ${#FILES[@]} -eq 0 ]; then
   echo "No matching files to delete in '$OUTPUT_DIR'."
 else
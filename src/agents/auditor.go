@@ -17,13 +17,18 @@ import (
 //go:embed prompts/auditor_prompt.txt
 var auditorPrompt string
 
+//go:embed prompts/auditor_prompt_figma.txt
+var auditorPromptFigma string
+
 // AuditResponse defines the expected JSON structure from the LLM
 type AuditResponse struct {
 	Issues []string `json:"issues"`
 }
 
-func Audit(narrative string, xml string) types.Critique {
-	prompt := buildAuditPrompt(narrative, xml)
+// Audit reviews a layout against the view narrative. The `format` should be
+// "drawio" or "figma" and selects the prompt for Draw.io XML or Figma JSON.
+func Audit(narrative string, xml string, format string) types.Critique {
+	prompt := buildAuditPrompt(narrative, xml, format)
 
 	// DEBUG: Uncomment this line to see the prompt sent to the auditor
 	// log.Printf("📝 DEBUG: Audit Prompt:\n%s\n", prompt)
@@ -37,8 +42,11 @@ func Audit(narrative string, xml string) types.Critique {
 	return types.Critique{Issues: issues}
 }
 
-func buildAuditPrompt(narrative string, xml string) string {
+func buildAuditPrompt(narrative string, xml string, format string) string {
 	prompt := auditorPrompt
+	if format == "figma" {
+		prompt = auditorPromptFigma
+	}
 	prompt = strings.ReplaceAll(prompt, "{{story}}", narrative)
 	prompt = strings.ReplaceAll(prompt, "{{xml}}", xml)
 	return prompt
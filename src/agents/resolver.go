@@ -18,44 +18,69 @@ import (
 //go:embed prompts/resolver_prompt.txt
 var resolverPrompt string
 
-// Resolve uses an LLM to repair layout XML based on critique feedback and view-specific narrative
-func Resolve(xml string, critique types.Critique, narrative string) string {
-	prompt := buildCorrectionPrompt(xml, critique.Issues, narrative)
+//go:embed prompts/resolver_prompt_figma.txt
+var resolverPromptFigma string
+
+// Resolve uses an LLM to repair a layout based on critique feedback and view-specific narrative.
+// The `format` should be "drawio" or "figma"; on any failure the input is returned unchanged.
+// It also returns the sanitizer fixes made to the model's Draw.io XML.
+func Resolve(xml string, critique types.Critique, narrative string, format string) (string, []string) {
+	template := resolverPrompt
+	if format == "figma" {
+		template = resolverPromptFigma
+	}
+	prompt := buildCorrectionPrompt(template, xml, critique.Issues, narrative)
 	// DEBUG: Uncomment this line to see the prompt sent to the resolver
 	// log.Printf("📝 DEBUG: Resolver Prompt:\n%s\n", prompt)
 
 	response, err := callOllamaForCorrection(prompt)
 	if err != nil {
 		log.Printf("❌ Resolver failed: %v", err)
-		return xml
+		return xml, nil
+	}
+
+	if format == "figma" {
+		// Figma overlaps are reported by the validator; there is no XML to reflow
+		extracted := extractFigmaJSON(response)
+		if extracted == "" || !json.Valid([]byte(extracted)) {
+			log.Printf("🚨 Resolver returned invalid or empty Figma JSON:\n%s\n", response)
+			return xml, nil
+		}
+		return extracted, nil
 	}
 
 	extractedXML := shared.ExtractXMLFrom(response)
 	if extractedXML == "" {
 		log.Printf("🚨 Resolver returned invalid or empty XML:\n%s\n", response)
-		return xml
+		return xml, nil
 	}
 
-	sanitizedXML, err := shared.SanitizeXML(extractedXML)
+	sanitizedXML, fixes, err := shared.SanitizeXMLFixes(extractedXML)
+	for _, fix := range fixes {
+		log.Printf("🧪 %s", fix)
+	}
 	if err != nil {
 		log.Printf("🚨 Sanitization failed: %v\nRaw XML:\n%s\n", err, extractedXML)
-		return xml
+		return xml, nil
 	}
 
 	// 🌐 Optional: Fix layout overlaps post-sanitization
-	fixedXML, err := shared.ResolveOverlaps(sanitizedXML, 10)
+	fixedXML, moves, err := shared.ResolveOverlapsReport(sanitizedXML, 10)
 	if err != nil {
 		log.Printf("⚠️ Layout correction failed: %v", err)
-		return sanitizedXML
+		return sanitizedXML, fixes
+	}
+	for _, m := range moves {
+		log.Printf("📐 %s", m)
 	}
 
 	// log.Printf("✅ Fixed Corrected XML:\n%s\n", sanitizedXML)
-	return fixedXML
+	return fixedXML, fixes
 }
 
 // buildCorrectionPrompt fills the embedded resolver prompt template with values
-func buildCorrectionPrompt(xml string, issues []string, narrative string) string {
-	prompt := resolverPrompt
+func buildCorrectionPrompt(template string, xml string, issues []string, narrative string) string {
+	prompt := template
 	prompt = strings.ReplaceAll(prompt, "{{issues}}", formatList(issues))
 	prompt = strings.ReplaceAll(prompt, "{{story}}", narrative)
 	prompt = strings.ReplaceAll(prompt, "{{xml}}", xml)
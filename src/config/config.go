@@ -0,0 +1,90 @@
+// src/config/config.go
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"holoplan-cli/src/validator"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPath is the project config picked up from the working directory.
+const DefaultPath = "holoplan.yaml"
+
+// Config is the project-level holoplan.yaml.
+type Config struct {
+	Rules   validator.Rules `yaml:"rules"`
+	Preview Preview         `yaml:"preview"`
+}
+
+// Preview sizes the PNG images written next to each layout.
+type Preview struct {
+	Scale   float64 `yaml:"scale"`   // pixels per layout px
+	Columns int     `yaml:"columns"` // thumbnails per row of the contact sheet
+}
+
+// maxScale keeps a mistyped scale from allocating a gigapixel image.
+const maxScale = 8
+
+// Default returns the built-in configuration.
+func Default() Config {
+	return Config{
+		Rules:   validator.DefaultRules(),
+		Preview: Preview{Scale: 1, Columns: 4},
+	}
+}
+
+// Check reports preview settings that cannot be rendered.
+func (p Preview) Check() error {
+	if p.Scale <= 0 || p.Scale > maxScale {
+		return fmt.Errorf("preview.scale must be in (0, %d], got %v", maxScale, p.Scale)
+	}
+	if p.Columns < 1 {
+		return fmt.Errorf("preview.columns must be at least 1, got %d", p.Columns)
+	}
+	return nil
+}
+
+// Load reads a config file on top of the defaults. A missing file at
+// DefaultPath is not an error; an explicitly requested one is.
+func Load(path string) (Config, error) {
+	cfg := Default()
+	if path == "" {
+		path = DefaultPath
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && path == DefaultPath {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	// Unknown keys are errors: a misspelt rule or setting would otherwise
+	// silently keep its default
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return cfg, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	if err := cfg.Rules.Check(); err != nil {
+		return cfg, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	if err := cfg.Preview.Check(); err != nil {
+		return cfg, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	for viewType := range cfg.Rules.Views {
+		if _, err := cfg.Rules.ForView(viewType); err != nil {
+			return cfg, fmt.Errorf("invalid config %s: %w", path, err)
+		}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name  string
+		yaml  string
+		want  string // error substring; empty if the file loads
+		check func(t *testing.T, cfg Config)
+	}{
+		{
+			name: "empty file keeps the defaults",
+			check: func(t *testing.T, cfg Config) {
+				if cfg.Preview != Default().Preview || !cfg.Rules.Collision.Enabled {
+					t.Errorf("defaults changed: %+v", cfg)
+				}
+			},
+		},
+		{
+			name: "set keys override the defaults",
+			yaml: "rules:\n  collision:\n    severity: warning\npreview:\n  columns: 2\n",
+			check: func(t *testing.T, cfg Config) {
+				if cfg.Rules.Collision.Severity != "warning" || !cfg.Rules.Collision.Enabled {
+					t.Errorf("collision = %+v", cfg.Rules.Collision)
+				}
+				if cfg.Preview.Columns != 2 || cfg.Preview.Scale != 1 {
+					t.Errorf("preview = %+v", cfg.Preview)
+				}
+			},
+		},
+		{name: "misspelt setting", yaml: "rules:\n  collision:\n    severty: warning\n", want: "field severty not found"},
+		{name: "misspelt section", yaml: "rules:\n  view_types:\n    modal: {}\n", want: "field view_types not found"},
+		{name: "unknown top-level key", yaml: "rule:\n  grid: {}\n", want: "field rule not found"},
+		{name: "misspelt view override", yaml: "rules:\n  views:\n    modal:\n      grid:\n        sise: 8\n", want: `view type "modal"`},
+		{name: "invalid threshold", yaml: "rules:\n  grid:\n    size: -10\n", want: "size must not be negative"},
+		{name: "invalid preview", yaml: "preview:\n  scale: 20\n", want: "preview.scale"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "holoplan.yaml")
+			if err := os.WriteFile(path, []byte(tt.yaml), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load(path)
+			if tt.want != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.want) {
+					t.Fatalf("err = %v, want %q", err, tt.want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestLoadExample(t *testing.T) {
+	if _, err := Load(filepath.Join("..", "..", "examples", "holoplan.yaml")); err != nil {
+		t.Fatalf("example config: %v", err)
+	}
+}
@@ -0,0 +1,248 @@
+// src/export/bmml.go
+package export
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"holoplan-cli/src/ir"
+	"holoplan-cli/src/taxonomy"
+)
+
+const (
+	// browserChrome is the height of a Balsamiq BrowserWindow's title and
+	// address bars; the view is laid out below them.
+	browserChrome = 70
+	bmmlControl   = "com.balsamiq.mockups::"
+)
+
+// bmmlItem is a Balsamiq control: its type, box and properties in order.
+type bmmlItem struct {
+	Type  string
+	Box   ir.Rect
+	Props [][2]string
+}
+
+// BMML renders a page as a Balsamiq mockup (.bmml) inside a BrowserWindow
+// titled title and addressed url, which may be empty for a blank address
+// bar. Widget kinds map to Balsamiq controls:
+// buttons, text inputs, search boxes, checkboxes, radios, combo boxes, tab
+// bars, data grids, lists, images, links, breadcrumbs, labels, titles and
+// group boxes. Tab bars, grids and lists take their child widgets as items;
+// connectors are left out.
+func BMML(p *ir.Page, title, url string) []byte {
+	w, h := p.Extent()
+	items := []bmmlItem{{
+		Type:  "BrowserWindow",
+		Box:   ir.Rect{Width: w, Height: h + browserChrome},
+		Props: [][2]string{{"text", bmmlText(title + "\n" + url)}},
+	}}
+
+	absorbed := make(map[*ir.Widget]bool)
+	p.Walk(func(wd *ir.Widget, abs ir.Rect, parent *ir.Widget) {
+		if absorbed[parent] {
+			absorbed[wd] = true
+			return
+		}
+		item, ok := bmmlWidget(wd, abs)
+		if !ok {
+			return
+		}
+		if len(wd.Children) > 0 && slices.Contains([]string{"TabBar", "DataGrid", "List", "BreadCrumbs"}, item.Type) {
+			absorbed[wd] = true
+		}
+		item.Box.Y += browserChrome
+		items = append(items, item)
+	})
+
+	var b bytes.Buffer
+	mw, mh := num(w), num(h+browserChrome)
+	fmt.Fprintf(&b, `<mockup version="1.0" skin="sketch" fontFace="Balsamiq Sans" measuredW="%s" measuredH="%s" mockupW="%s" mockupH="%s">`+"\n", mw, mh, mw, mh)
+	b.WriteString("  <controls>\n")
+	for i, it := range items {
+		fmt.Fprintf(&b, `    <control controlID="%d" controlTypeID="%s%s" x="%s" y="%s" w="%s" h="%s" measuredW="%s" measuredH="%s" zOrder="%d" locked="false" isInGroup="-1">`+"\n",
+			i, bmmlControl, it.Type, num(it.Box.X), num(it.Box.Y), num(it.Box.Width), num(it.Box.Height), num(it.Box.Width), num(it.Box.Height), i)
+		if len(it.Props) > 0 {
+			b.WriteString("      <controlProperties>\n")
+			for _, prop := range it.Props {
+				fmt.Fprintf(&b, "        <%s>%s</%s>\n", prop[0], prop[1], prop[0])
+			}
+			b.WriteString("      </controlProperties>\n")
+		}
+		b.WriteString("    </control>\n")
+	}
+	b.WriteString("  </controls>\n</mockup>\n")
+	return b.Bytes()
+}
+
+// bmmlWidget maps a widget to its Balsamiq control; decorations with neither
+// text nor paint have none.
+func bmmlWidget(w *ir.Widget, abs ir.Rect) (bmmlItem, bool) {
+	lines := ir.Lines(w.Label)
+	text := strings.Join(lines, " ")
+	painted := (w.Style.Fill != "" && w.Style.Fill != "none") || (w.Style.Stroke != "" && w.Style.Stroke != "none")
+	container := len(w.Children) > 0
+
+	item := bmmlItem{Box: abs}
+	label := func(s string) { item.Props = append(item.Props, [2]string{"text", bmmlText(s)}) }
+	color := func(hex string) {
+		if r, g, b, ok := ir.ParseHex(hex); ok {
+			item.Props = append(item.Props, [2]string{"color", strconv.Itoa(int(r)<<16 | int(g)<<8 | int(b))})
+		}
+	}
+
+	switch kind := w.Kind; {
+	case kind == taxonomy.Button || kind == taxonomy.FAB:
+		item.Type = "Button"
+		label(text)
+		color(w.Style.Fill)
+	case kind == taxonomy.Input:
+		item.Type = "TextInput"
+		label(text)
+	case kind == taxonomy.Search:
+		item.Type = "SearchBox"
+		label(text)
+	case kind == taxonomy.Checkbox:
+		item.Type = "CheckBox"
+		label(text)
+	case kind == taxonomy.Radio:
+		item.Type = "RadioButton"
+		label(text)
+	case kind == taxonomy.Dropdown:
+		item.Type = "ComboBox"
+		label(text)
+	case kind == taxonomy.Tabs:
+		item.Type = "TabBar"
+		label(strings.Join(bmmlItems(w), ", "))
+	case kind == taxonomy.Navbar && !container && text != "":
+		item.Type = "ButtonBar"
+		label(strings.Join(bmmlItems(w), ", "))
+	case kind == taxonomy.Table:
+		item.Type = "DataGrid"
+		label(strings.Join(bmmlRows(w, abs, lines), "\n"))
+	case kind == taxonomy.List:
+		item.Type = "List"
+		label(strings.Join(bmmlItems(w), "\n"))
+	case kind == taxonomy.Image:
+		item.Type = "Image"
+	case kind == taxonomy.Link:
+		item.Type = "Link"
+		label(text)
+	case kind == taxonomy.Breadcrumb:
+		item.Type = "BreadCrumbs"
+		label(strings.Join(bmmlItems(w), ", "))
+	case kind == taxonomy.Modal:
+		item.Type = "TitleWindow"
+		label(text)
+	case kind == taxonomy.Header && !container && text != "":
+		item.Type = "Title"
+		label(text)
+	case container || kind == taxonomy.Card || kind == taxonomy.Form ||
+		kind == taxonomy.Navbar || kind == taxonomy.Header || kind == taxonomy.Footer || kind == taxonomy.Sidebar:
+		if text != "" {
+			item.Type = "FieldSet"
+			label(text)
+		} else {
+			item.Type = "Canvas"
+			color(w.Style.Fill)
+		}
+	case text != "":
+		item.Type = "Label"
+		if len(lines) > 1 {
+			item.Type = "Paragraph"
+			label(strings.Join(lines, "\n"))
+		} else {
+			label(text)
+		}
+		if w.Style.FontSize > 0 && w.Style.FontSize != ir.DefaultFontSize {
+			item.Props = append(item.Props, [2]string{"size", num(w.Style.FontSize)})
+		}
+		if w.Style.Bold {
+			item.Props = append(item.Props, [2]string{"bold", "true"})
+		}
+		color(w.Style.FontColor)
+	case painted:
+		item.Type = "Canvas"
+		color(w.Style.Fill)
+	default:
+		return item, false
+	}
+	return item, true
+}
+
+// bmmlItems returns a widget's items: its children's labels in reading
+// order, or else the parts of its own label.
+func bmmlItems(w *ir.Widget) []string {
+	var items []string
+	for _, c := range readingOrder(w.Children) {
+		if t := strings.Join(ir.Lines(c.Label), " "); t != "" {
+			items = append(items, bmmlCell(t))
+		}
+	}
+	if len(items) > 0 {
+		return items
+	}
+	return splitItems(strings.NewReplacer(">", "/", "›", "/", "\n", "|").Replace(w.Text()))
+}
+
+// bmmlRows returns a table's rows as comma-separated cells: its children
+// grouped into rows by position, or else its label's lines.
+func bmmlRows(w *ir.Widget, abs ir.Rect, lines []string) []string {
+	var nodes []*saltNode
+	for _, c := range w.Children {
+		if t := strings.Join(ir.Lines(c.Label), " "); t != "" {
+			box := c.Geometry
+			box.X += abs.X
+			box.Y += abs.Y
+			nodes = append(nodes, &saltNode{Box: box, Text: t})
+		}
+	}
+	var rows []string
+	for _, band := range cut(nodes, func(r ir.Rect) (float64, float64) { return r.Y, r.Y + r.Height }) {
+		slices.SortStableFunc(band, func(a, b *saltNode) int { return cmp.Compare(a.Box.X, b.Box.X) })
+		var cells []string
+		for _, n := range band {
+			cells = append(cells, bmmlCell(n.Text))
+		}
+		rows = append(rows, strings.Join(cells, ","))
+	}
+	if len(rows) > 0 {
+		return rows
+	}
+	for _, l := range lines {
+		rows = append(rows, strings.Join(splitItems(l), ","))
+	}
+	return rows
+}
+
+// readingOrder sorts widgets top to bottom, then left to right.
+func readingOrder(ws []*ir.Widget) []*ir.Widget {
+	sorted := slices.Clone(ws)
+	slices.SortStableFunc(sorted, func(a, b *ir.Widget) int {
+		return cmp.Or(cmp.Compare(a.Geometry.Y, b.Geometry.Y), cmp.Compare(a.Geometry.X, b.Geometry.X))
+	})
+	return sorted
+}
+
+// bmmlCell keeps an item from splitting at Balsamiq's separators.
+func bmmlCell(s string) string {
+	return strings.TrimSpace(strings.NewReplacer(",", " ", "\n", " ").Replace(s))
+}
+
+// bmmlText encodes text the way Balsamiq stores it, as with JavaScript's
+// encodeURIComponent, which also keeps it safe inside XML.
+func bmmlText(s string) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || strings.IndexByte("-_.!~*'()", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,76 @@
+package export
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"holoplan-cli/src/ir"
+	"holoplan-cli/src/taxonomy"
+)
+
+func TestBMMLWidget(t *testing.T) {
+	box := ir.Rect{Width: 300, Height: 100}
+	child := func(label string, x, y float64) *ir.Widget {
+		return &ir.Widget{Kind: taxonomy.Text, Label: label, Geometry: ir.Rect{X: x, Y: y, Width: 80, Height: 20}}
+	}
+	tests := []struct {
+		name     string
+		widget   ir.Widget
+		wantType string // empty for no control
+		wantText string // decoded text property
+	}{
+		{name: "button", widget: ir.Widget{Kind: taxonomy.Button, Label: "Save"}, wantType: "Button", wantText: "Save"},
+		{name: "floating action button", widget: ir.Widget{Kind: taxonomy.FAB, Label: "+"}, wantType: "Button", wantText: "+"},
+		{name: "search", widget: ir.Widget{Kind: taxonomy.Search, Label: "Search plants"}, wantType: "SearchBox", wantText: "Search plants"},
+		{name: "dropdown", widget: ir.Widget{Kind: taxonomy.Dropdown, Label: "Country"}, wantType: "ComboBox", wantText: "Country"},
+		{name: "tabs from children in reading order", widget: ir.Widget{Kind: taxonomy.Tabs, Children: []*ir.Widget{
+			child("Reviews", 200, 0), child("Overview", 0, 0), child("Specs, sizes", 100, 0),
+		}}, wantType: "TabBar", wantText: "Overview, Specs  sizes, Reviews"},
+		{name: "breadcrumb from its label", widget: ir.Widget{Kind: taxonomy.Breadcrumb, Label: "Home > Plants > Fern"}, wantType: "BreadCrumbs", wantText: "Home, Plants, Fern"},
+		{name: "table rows from children", widget: ir.Widget{Kind: taxonomy.Table, Children: []*ir.Widget{
+			child("Name", 0, 0), child("Price", 100, 0), child("Fern", 0, 30), child("$12", 100, 30),
+		}}, wantType: "DataGrid", wantText: "Name,Price\nFern,$12"},
+		{name: "titled card", widget: ir.Widget{Kind: taxonomy.Card, Label: "Details"}, wantType: "FieldSet", wantText: "Details"},
+		{name: "untitled container", widget: ir.Widget{Children: []*ir.Widget{child("x", 0, 0)}}, wantType: "Canvas"},
+		{name: "header text is a title", widget: ir.Widget{Kind: taxonomy.Header, Label: "My garden"}, wantType: "Title", wantText: "My garden"},
+		{name: "multi-line text", widget: ir.Widget{Kind: taxonomy.Text, Label: "One<br>Two"}, wantType: "Paragraph", wantText: "One\nTwo"},
+		{name: "bare decoration", widget: ir.Widget{Kind: taxonomy.Unknown}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, ok := bmmlWidget(&tt.widget, box)
+			if !ok {
+				if tt.wantType != "" {
+					t.Fatalf("no control, want %s", tt.wantType)
+				}
+				return
+			}
+			if item.Type != tt.wantType {
+				t.Errorf("type = %q, want %q", item.Type, tt.wantType)
+			}
+			var text string
+			if i := slices.IndexFunc(item.Props, func(p [2]string) bool { return p[0] == "text" }); i >= 0 {
+				text = item.Props[i][1]
+			}
+			if want := bmmlText(tt.wantText); text != want {
+				t.Errorf("text = %q, want %q", text, want)
+			}
+		})
+	}
+}
+
+func TestBMML(t *testing.T) {
+	p := drawioPage(t, "Save:20,10,120,40")
+	out := string(BMML(p, "US-1 · Home", ""))
+
+	for _, want := range []string{
+		`controlTypeID="com.balsamiq.mockups::BrowserWindow" x="0" y="0"`,
+		"<text>US-1%20%C2%B7%20Home%0A</text>",
+		`controlTypeID="com.balsamiq.mockups::Label" x="20" y="80" w="120" h="40"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("mockup lacks %s:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// src/export/canvas.go
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	canvasPad       = 40  // inside a story group
+	canvasGap       = 40  // between nodes and between groups
+	canvasTextWidth = 360 // narrative nodes
+	canvasViewMax   = 360 // longest side of a view node
+	canvasFontSize  = 16  // canvas apps' default text size
+)
+
+// CanvasStory is one story of a story map: a group holding its narrative and
+// its views.
+type CanvasStory struct {
+	ID        string
+	Title     string
+	Narrative string
+	Views     []CanvasView
+}
+
+// CanvasView is a view node. File embeds a preview image or other file by
+// path; without one the node is a text card naming the view. Width and
+// Height give its aspect ratio, if known.
+type CanvasView struct {
+	ID            string
+	Name          string
+	File          string
+	Width, Height float64
+}
+
+// CanvasEdge is a navigation step between two view nodes.
+type CanvasEdge struct {
+	From, To string // CanvasView IDs
+	Label    string
+}
+
+type canvasNode struct {
+	ID     string `json:"id"`
+	Type   string `json:"type"`
+	X      int    `json:"x"`
+	Y      int    `json:"y"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Text   string `json:"text,omitempty"`
+	File   string `json:"file,omitempty"`
+	Label  string `json:"label,omitempty"`
+}
+
+type canvasEdge struct {
+	ID       string `json:"id"`
+	FromNode string `json:"fromNode"`
+	FromSide string `json:"fromSide"`
+	ToNode   string `json:"toNode"`
+	ToSide   string `json:"toSide"`
+	ToEnd    string `json:"toEnd"`
+	Label    string `json:"label,omitempty"`
+}
+
+// Canvas renders a story map in the JSON Canvas format (.canvas, as read by
+// Obsidian and other canvas tools): one group per story, stacked top to
+// bottom, with the story's narrative as a text node followed by its views
+// left to right, and arrows between views for the navigation edges.
+func Canvas(stories []CanvasStory, edges []CanvasEdge) ([]byte, error) {
+	var nodes []canvasNode
+	views := make(map[string]canvasNode)
+	y := 0
+	for _, st := range stories {
+		title := strings.TrimSuffix(st.ID+" · "+st.Title, " · ")
+		text := "## " + title
+		if n := strings.TrimSpace(st.Narrative); n != "" {
+			text += "\n\n" + n
+		}
+		if len(st.Views) == 0 {
+			text += "\n\n*No views generated.*"
+		}
+		narrative := canvasNode{
+			ID: "story-" + st.ID + "-narrative", Type: "text",
+			X: canvasPad, Y: y + canvasPad, Width: canvasTextWidth, Height: canvasTextHeight(text),
+			Text: text,
+		}
+
+		x, height := narrative.X+narrative.Width+canvasGap, narrative.Height
+		var members []canvasNode
+		for _, v := range st.Views {
+			w, h := canvasFit(v.Width, v.Height)
+			node := canvasNode{ID: v.ID, X: x, Y: y + canvasPad, Width: w, Height: h}
+			if v.File != "" {
+				node.Type, node.File = "file", v.File
+			} else {
+				node.Type, node.Text = "text", "**"+v.Name+"**"
+			}
+			members = append(members, node)
+			views[v.ID] = node
+			x += w + canvasGap
+			height = max(height, h)
+		}
+
+		nodes = append(nodes, canvasNode{
+			ID: "story-" + st.ID, Type: "group",
+			X: 0, Y: y, Width: x - canvasGap + canvasPad, Height: height + 2*canvasPad,
+			Label: title,
+		}, narrative)
+		nodes = append(nodes, members...)
+		y += height + 2*canvasPad + canvasGap
+	}
+
+	var out []canvasEdge
+	for _, e := range edges {
+		from, ok1 := views[e.From]
+		to, ok2 := views[e.To]
+		if !ok1 || !ok2 {
+			continue
+		}
+		fromSide, toSide := canvasSides(from, to)
+		out = append(out, canvasEdge{
+			ID:       fmt.Sprintf("edge-%d", len(out)+1),
+			FromNode: e.From, FromSide: fromSide,
+			ToNode: e.To, ToSide: toSide,
+			ToEnd: "arrow", Label: e.Label,
+		})
+	}
+
+	data, err := json.MarshalIndent(struct {
+		Nodes []canvasNode `json:"nodes"`
+		Edges []canvasEdge `json:"edges"`
+	}{nodes, out}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode canvas: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// canvasTextHeight estimates the height of a text node wrapped to the
+// narrative width.
+func canvasTextHeight(text string) int {
+	lines := 0
+	for _, l := range strings.Split(text, "\n") {
+		lines += max(1, len(wrap([]string{l}, canvasTextWidth-2*canvasFontSize, canvasFontSize)))
+	}
+	return int(math.Ceil(float64(lines)*canvasFontSize*lineHeight)) + 2*canvasFontSize
+}
+
+// canvasFit scales a view to fit canvasViewMax on its longer side; views of
+// unknown size get a 4:3 card.
+func canvasFit(w, h float64) (int, int) {
+	if w <= 0 || h <= 0 {
+		return canvasViewMax, canvasViewMax * 3 / 4
+	}
+	k := canvasViewMax / max(w, h)
+	return int(math.Round(w * k)), int(math.Round(h * k))
+}
+
+// canvasSides picks the sides an edge leaves and enters by, facing each
+// other along the axis the nodes are further apart on.
+func canvasSides(from, to canvasNode) (string, string) {
+	dx := float64(to.X+to.Width/2) - float64(from.X+from.Width/2)
+	dy := float64(to.Y+to.Height/2) - float64(from.Y+from.Height/2)
+	switch {
+	case math.Abs(dx) >= math.Abs(dy) && dx >= 0:
+		return "right", "left"
+	case math.Abs(dx) >= math.Abs(dy):
+		return "left", "right"
+	case dy >= 0:
+		return "bottom", "top"
+	default:
+		return "top", "bottom"
+	}
+}
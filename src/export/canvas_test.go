@@ -0,0 +1,87 @@
+package export
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCanvas(t *testing.T) {
+	stories := []CanvasStory{
+		{ID: "US-1", Title: "Browse", Narrative: "As a shopper I browse plants.", Views: []CanvasView{
+			{ID: "v1", Name: "Home", File: "us-1_home.png", Width: 800, Height: 600},
+			{ID: "v2", Name: "Results"},
+		}},
+		{ID: "US-2", Views: []CanvasView{{ID: "v3", Name: "Detail", File: "us-2_detail.png", Width: 300, Height: 900}}},
+		{ID: "US-3", Title: "Checkout"},
+	}
+	edges := []CanvasEdge{
+		{From: "v1", To: "v2", Label: "US-1"},
+		{From: "v2", To: "v3"},
+		{From: "v3", To: "v1"},
+		{From: "v3", To: "missing"},
+	}
+	data, err := Canvas(stories, edges)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Nodes []canvasNode `json:"nodes"`
+		Edges []canvasEdge `json:"edges"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	byID := make(map[string]canvasNode)
+	var groups []canvasNode
+	for _, n := range got.Nodes {
+		byID[n.ID] = n
+		if n.Type == "group" {
+			groups = append(groups, n)
+		}
+	}
+	for i := 1; i < len(groups); i++ {
+		if above := groups[i-1]; groups[i].Y < above.Y+above.Height+canvasGap {
+			t.Errorf("group %s starts at y=%d, inside or too close to %s", groups[i].ID, groups[i].Y, above.ID)
+		}
+	}
+	inside := func(n, g canvasNode) bool {
+		return n.X >= g.X+canvasPad && n.Y >= g.Y+canvasPad && n.X+n.Width <= g.X+g.Width-canvasPad && n.Y+n.Height <= g.Y+g.Height-canvasPad
+	}
+	for node, group := range map[string]string{
+		"story-US-1-narrative": "story-US-1", "v1": "story-US-1", "v2": "story-US-1",
+		"story-US-2-narrative": "story-US-2", "v3": "story-US-2", "story-US-3-narrative": "story-US-3",
+	} {
+		if !inside(byID[node], byID[group]) {
+			t.Errorf("%s %+v is not inside %s %+v", node, byID[node], group, byID[group])
+		}
+	}
+
+	if v := byID["v1"]; v.Type != "file" || v.Width != canvasViewMax || v.Height != 270 {
+		t.Errorf("v1 = %+v, want a %dx270 file node", v, canvasViewMax)
+	}
+	if v := byID["v2"]; v.Type != "text" || v.Text != "**Results**" {
+		t.Errorf("v2 = %+v, want a text card", v)
+	}
+	if v := byID["v3"]; v.Width != 120 || v.Height != canvasViewMax {
+		t.Errorf("v3 is %dx%d, want 120x%d", v.Width, v.Height, canvasViewMax)
+	}
+	if n := byID["story-US-3-narrative"]; !strings.Contains(n.Text, "No views generated") {
+		t.Errorf("US-3 narrative = %q", n.Text)
+	}
+
+	want := []canvasEdge{
+		{ID: "edge-1", FromNode: "v1", FromSide: "right", ToNode: "v2", ToSide: "left", ToEnd: "arrow", Label: "US-1"},
+		{ID: "edge-2", FromNode: "v2", FromSide: "left", ToNode: "v3", ToSide: "right", ToEnd: "arrow"},
+		{ID: "edge-3", FromNode: "v3", FromSide: "top", ToNode: "v1", ToSide: "bottom", ToEnd: "arrow"},
+	}
+	if len(got.Edges) != len(want) {
+		t.Fatalf("edges = %+v, want %+v", got.Edges, want)
+	}
+	for i := range want {
+		if got.Edges[i] != want[i] {
+			t.Errorf("edge %d = %+v, want %+v", i+1, got.Edges[i], want[i])
+		}
+	}
+}
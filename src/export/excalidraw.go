@@ -0,0 +1,314 @@
+// src/export/excalidraw.go
+package export
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+
+	"holoplan-cli/src/ir"
+)
+
+const (
+	// excalidrawFont is Excalidraw's Helvetica font family.
+	excalidrawFont = 2
+	// excalidrawLineHeight is Excalidraw's line height for Helvetica.
+	excalidrawLineHeight = 1.25
+	// frameGap separates the frames of a combined scene.
+	frameGap = 100
+)
+
+// excalidrawScene is the top level of a .excalidraw file.
+type excalidrawScene struct {
+	Type     string               `json:"type"`
+	Version  int                  `json:"version"`
+	Source   string               `json:"source"`
+	Elements []*excalidrawElement `json:"elements"`
+	AppState map[string]any       `json:"appState"`
+	Files    map[string]any       `json:"files"`
+}
+
+// excalidrawElement holds the fields of every element type; those that only
+// apply to text, linear elements or frames are left out when empty.
+type excalidrawElement struct {
+	ID              string               `json:"id"`
+	Type            string               `json:"type"`
+	X               float64              `json:"x"`
+	Y               float64              `json:"y"`
+	Width           float64              `json:"width"`
+	Height          float64              `json:"height"`
+	Angle           float64              `json:"angle"`
+	StrokeColor     string               `json:"strokeColor"`
+	BackgroundColor string               `json:"backgroundColor"`
+	FillStyle       string               `json:"fillStyle"`
+	StrokeWidth     float64              `json:"strokeWidth"`
+	StrokeStyle     string               `json:"strokeStyle"`
+	Roughness       int                  `json:"roughness"`
+	Opacity         int                  `json:"opacity"`
+	GroupIDs        []string             `json:"groupIds"`
+	FrameID         *string              `json:"frameId"`
+	Roundness       *excalidrawRoundness `json:"roundness"`
+	Seed            uint32               `json:"seed"`
+	Version         int                  `json:"version"`
+	VersionNonce    uint32               `json:"versionNonce"`
+	IsDeleted       bool                 `json:"isDeleted"`
+	BoundElements   []excalidrawRef      `json:"boundElements"`
+	Updated         int64                `json:"updated"`
+	Link            *string              `json:"link"`
+	Locked          bool                 `json:"locked"`
+
+	Text          string  `json:"text,omitempty"`
+	OriginalText  string  `json:"originalText,omitempty"`
+	FontSize      float64 `json:"fontSize,omitempty"`
+	FontFamily    int     `json:"fontFamily,omitempty"`
+	TextAlign     string  `json:"textAlign,omitempty"`
+	VerticalAlign string  `json:"verticalAlign,omitempty"`
+	ContainerID   *string `json:"containerId,omitempty"`
+	LineHeight    float64 `json:"lineHeight,omitempty"`
+
+	Points       [][2]float64       `json:"points,omitempty"`
+	StartBinding *excalidrawBinding `json:"startBinding,omitempty"`
+	EndBinding   *excalidrawBinding `json:"endBinding,omitempty"`
+	EndArrowhead string             `json:"endArrowhead,omitempty"`
+
+	Name string `json:"name,omitempty"`
+}
+
+type excalidrawRoundness struct {
+	Type int `json:"type"`
+}
+
+type excalidrawRef struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+type excalidrawBinding struct {
+	ElementID string  `json:"elementId"`
+	Focus     float64 `json:"focus"`
+	Gap       float64 `json:"gap"`
+}
+
+// Excalidraw renders a page as an Excalidraw scene: shapes as rectangles and
+// ellipses with their labels bound as text, and connectors as arrows bound
+// to the shapes they join.
+func Excalidraw(p *ir.Page) ([]byte, error) {
+	return marshalExcalidraw(excalidrawPage(newScene(p), "", 0, nil))
+}
+
+// ExcalidrawDocument renders every page of a document side by side in one
+// scene, each inside a frame named after it.
+func ExcalidrawDocument(d *ir.Document) ([]byte, error) {
+	var elements []*excalidrawElement
+	x := 0.0
+	for i, p := range d.Pages {
+		sc := newScene(p)
+		frameID := fmt.Sprintf("frame-%d", i+1)
+		elements = append(elements, excalidrawPage(sc, fmt.Sprintf("%d:", i+1), x, &frameID)...)
+
+		// Excalidraw keeps a frame after its children
+		frame := newExcalidrawElement(frameID, "frame", ir.Rect{X: x, Width: sc.Width, Height: sc.Height})
+		frame.Name = p.Name
+		frame.StrokeColor = "#bbbbbb"
+		elements = append(elements, frame)
+		x += sc.Width + frameGap
+	}
+	return marshalExcalidraw(elements)
+}
+
+func marshalExcalidraw(elements []*excalidrawElement) ([]byte, error) {
+	out, err := json.MarshalIndent(excalidrawScene{
+		Type:     "excalidraw",
+		Version:  2,
+		Source:   "holoplan",
+		Elements: elements,
+		AppState: map[string]any{"gridSize": nil, "viewBackgroundColor": "#ffffff"},
+		Files:    map[string]any{},
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode Excalidraw scene: %w", err)
+	}
+	return append(out, '\n'), nil
+}
+
+// excalidrawPage converts a scene to elements, shifted right by dx, with IDs
+// prefixed to keep them unique in a combined scene.
+func excalidrawPage(sc scene, prefix string, dx float64, frameID *string) []*excalidrawElement {
+	var elements []*excalidrawElement
+	byShape := make(map[string]*excalidrawElement)
+	add := func(e *excalidrawElement) *excalidrawElement {
+		e.X += dx
+		e.FrameID = frameID
+		elements = append(elements, e)
+		return e
+	}
+
+	for _, s := range sc.Shapes {
+		id := prefix + s.ID
+		var box *excalidrawElement
+		if s.Box.Width > 0 && s.Box.Height > 0 && (s.Fill != "" || s.Stroke != "") {
+			kind := "rectangle"
+			if s.Ellipse {
+				kind = "ellipse"
+			}
+			box = add(newExcalidrawElement(id, kind, s.Box))
+			box.StrokeColor = excalidrawColor(s.Stroke)
+			box.BackgroundColor = excalidrawColor(s.Fill)
+			box.StrokeWidth = s.StrokeWidth
+			if s.Radius > 0 && !s.Ellipse {
+				box.Roundness = &excalidrawRoundness{Type: 3}
+			}
+			byShape[s.ID] = box
+		}
+		if s.Placeholder && s.Box.Width > 0 && s.Box.Height > 0 {
+			b := s.Box
+			for i, pts := range [][2]ir.Point{
+				{{X: b.X, Y: b.Y}, {X: b.X + b.Width, Y: b.Y + b.Height}},
+				{{X: b.X + b.Width, Y: b.Y}, {X: b.X, Y: b.Y + b.Height}},
+			} {
+				line := add(newExcalidrawLinear(fmt.Sprintf("%s-x%d", id, i+1), "line", pts[:]))
+				line.StrokeColor = "#999999"
+			}
+		}
+
+		if len(s.Lines) == 0 {
+			continue
+		}
+		text := add(newExcalidrawText(id+"-text", s.Lines, s.FontSize, s.textBox(), s.Align, s.VAlign))
+		text.StrokeColor = excalidrawColor(s.FontColor)
+		if box != nil {
+			bindText(box, text)
+		} else {
+			byShape[s.ID] = text
+		}
+	}
+
+	for i, c := range sc.Connectors {
+		id := prefix + cmp.Or(c.ID, fmt.Sprintf("edge-%d", i+1))
+		arrow := add(newExcalidrawLinear(id, "arrow", c.Points))
+		arrow.StrokeColor = excalidrawColor(c.Stroke)
+		arrow.EndArrowhead = "arrow"
+		for _, end := range []struct {
+			shape   string
+			binding **excalidrawBinding
+		}{{c.Source, &arrow.StartBinding}, {c.Target, &arrow.EndBinding}} {
+			if el, ok := byShape[end.shape]; ok && end.shape != "" {
+				*end.binding = &excalidrawBinding{ElementID: el.ID, Gap: 1}
+				el.BoundElements = append(el.BoundElements, excalidrawRef{ID: arrow.ID, Type: "arrow"})
+			}
+		}
+
+		if c.Label != "" {
+			mid := c.Points[len(c.Points)/2]
+			if len(c.Points)%2 == 0 {
+				a := c.Points[len(c.Points)/2-1]
+				mid = ir.Point{X: (a.X + mid.X) / 2, Y: (a.Y + mid.Y) / 2}
+			}
+			lines := strings.Split(c.Label, "\n")
+			w, h := textSize(lines, 11)
+			label := add(newExcalidrawText(id+"-label", lines, 11, ir.Rect{X: mid.X - w/2, Y: mid.Y - h/2, Width: w, Height: h}, "center", "middle"))
+			label.StrokeColor = arrow.StrokeColor
+			bindText(arrow, label)
+		}
+	}
+	return elements
+}
+
+func newExcalidrawElement(id, kind string, box ir.Rect) *excalidrawElement {
+	return &excalidrawElement{
+		ID:              id,
+		Type:            kind,
+		X:               box.X,
+		Y:               box.Y,
+		Width:           box.Width,
+		Height:          box.Height,
+		StrokeColor:     "#000000",
+		BackgroundColor: "transparent",
+		FillStyle:       "solid",
+		StrokeWidth:     1,
+		StrokeStyle:     "solid",
+		Opacity:         100,
+		GroupIDs:        []string{},
+		Seed:            excalidrawSeed(id),
+		Version:         1,
+		VersionNonce:    excalidrawSeed(id + "#"),
+		Updated:         1,
+	}
+}
+
+// newExcalidrawLinear builds a line or arrow through absolute points;
+// Excalidraw stores them relative to the element's position.
+func newExcalidrawLinear(id, kind string, pts []ir.Point) *excalidrawElement {
+	minX, minY, maxX, maxY := pts[0].X, pts[0].Y, pts[0].X, pts[0].Y
+	for _, p := range pts {
+		minX, minY = min(minX, p.X), min(minY, p.Y)
+		maxX, maxY = max(maxX, p.X), max(maxY, p.Y)
+	}
+	e := newExcalidrawElement(id, kind, ir.Rect{X: pts[0].X, Y: pts[0].Y, Width: maxX - minX, Height: maxY - minY})
+	for _, p := range pts {
+		e.Points = append(e.Points, [2]float64{p.X - pts[0].X, p.Y - pts[0].Y})
+	}
+	return e
+}
+
+func newExcalidrawText(id string, lines []string, fontSize float64, box ir.Rect, align, valign string) *excalidrawElement {
+	e := newExcalidrawElement(id, "text", box)
+	e.Text = strings.Join(lines, "\n")
+	e.OriginalText = e.Text
+	e.FontSize = fontSize
+	e.FontFamily = excalidrawFont
+	e.TextAlign = align
+	e.VerticalAlign = valign
+	e.LineHeight = excalidrawLineHeight
+	return e
+}
+
+// bindText makes text the label of a container, which Excalidraw then keeps
+// positioned inside it.
+func bindText(container, text *excalidrawElement) {
+	text.ContainerID = &container.ID
+	container.BoundElements = append(container.BoundElements, excalidrawRef{ID: text.ID, Type: "text"})
+}
+
+// textBox estimates where a shape's label sits, for Excalidraw to refine.
+func (s shape) textBox() ir.Rect {
+	w, h := textSize(s.Lines, s.FontSize)
+	x, y := s.textOrigin()
+	switch s.Align {
+	case "center":
+		x -= w / 2
+	case "right":
+		x -= w
+	}
+	// textOrigin is the first baseline; the box starts a line's ascent above it
+	y -= s.FontSize * 0.8
+	return ir.Rect{X: x, Y: y, Width: w, Height: h}
+}
+
+func textSize(lines []string, fontSize float64) (float64, float64) {
+	longest := 0
+	for _, l := range lines {
+		longest = max(longest, len([]rune(l)))
+	}
+	round := func(f float64) float64 { return math.Round(f*100) / 100 }
+	return round(float64(longest) * fontSize * charWidth), round(float64(len(lines)) * fontSize * excalidrawLineHeight)
+}
+
+// excalidrawColor maps a resolved color to Excalidraw's, where none is
+// "transparent".
+func excalidrawColor(color string) string {
+	if color == "" {
+		return "transparent"
+	}
+	return color
+}
+
+// excalidrawSeed derives a stable seed so repeated exports are identical.
+func excalidrawSeed(id string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	return h.Sum32()>>1 + 1
+}
@@ -0,0 +1,72 @@
+package export
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"holoplan-cli/src/ir"
+)
+
+func TestExcalidrawBindings(t *testing.T) {
+	doc, err := ir.FromDrawio(flow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := doc.Pages[0]
+
+	tests := []struct {
+		name   string
+		render func() ([]byte, error)
+		prefix string // of the IDs of the first page's elements
+	}{
+		{name: "page", render: func() ([]byte, error) { return Excalidraw(page) }},
+		{name: "document", render: func() ([]byte, error) {
+			return ExcalidrawDocument(&ir.Document{Pages: []*ir.Page{page, page}})
+		}, prefix: "1:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.render()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var sc excalidrawScene
+			if err := json.Unmarshal(data, &sc); err != nil {
+				t.Fatal(err)
+			}
+			byID := make(map[string]*excalidrawElement)
+			for _, e := range sc.Elements {
+				if byID[e.ID] != nil {
+					t.Errorf("duplicate element id %q", e.ID)
+				}
+				byID[e.ID] = e
+			}
+			// every binding must be mirrored in the bound element's boundElements
+			refers := func(e *excalidrawElement, id, kind string) bool {
+				return e != nil && slices.Contains(e.BoundElements, excalidrawRef{ID: id, Type: kind})
+			}
+			for _, e := range sc.Elements {
+				for _, b := range []*excalidrawBinding{e.StartBinding, e.EndBinding} {
+					if b != nil && !refers(byID[b.ElementID], e.ID, "arrow") {
+						t.Errorf("arrow %s is bound to %s, which does not list it", e.ID, b.ElementID)
+					}
+				}
+				if e.ContainerID != nil && !refers(byID[*e.ContainerID], e.ID, "text") {
+					t.Errorf("text %s is in %s, which does not list it", e.ID, *e.ContainerID)
+				}
+			}
+
+			e1 := byID[tt.prefix+"e1"]
+			if e1 == nil || e1.StartBinding == nil || e1.EndBinding == nil {
+				t.Fatalf("arrow e1 = %+v, want bound at both ends", e1)
+			}
+			if e1.StartBinding.ElementID != tt.prefix+"a" || e1.EndBinding.ElementID != tt.prefix+"b" {
+				t.Errorf("e1 joins %s to %s, want %sa to %sb", e1.StartBinding.ElementID, e1.EndBinding.ElementID, tt.prefix, tt.prefix)
+			}
+			if label := byID[tt.prefix+"e1-label"]; label == nil || label.ContainerID == nil || *label.ContainerID != e1.ID {
+				t.Errorf("e1's label is not bound to it: %+v", label)
+			}
+		})
+	}
+}
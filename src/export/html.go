@@ -0,0 +1,247 @@
+// src/export/html.go
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"holoplan-cli/src/ir"
+	"holoplan-cli/src/taxonomy"
+)
+
+// Link is a navigation target of a prototype page.
+type Link struct {
+	Label string // shown in the page's navigation bar
+	Href  string
+	// Target names the view the link leads to. The first clickable widget
+	// whose text shares a word with it becomes a hotspot for the link; an
+	// empty Target only adds the link to the navigation bar.
+	Target string
+}
+
+// IndexStory is one story on a prototype's index page.
+type IndexStory struct {
+	ID        string
+	Title     string
+	Narrative string
+	Views     []IndexView
+}
+
+// IndexView is a link to one prototype page, with an optional thumbnail.
+type IndexView struct {
+	Name  string
+	Href  string
+	Image string
+}
+
+// hotspotKinds are the widgets that can carry a link, most likely first;
+// unclassified widgets are often buttons the taxonomy has no word for.
+var hotspotKinds = []taxonomy.Kind{taxonomy.Button, taxonomy.Link, taxonomy.Tabs, taxonomy.Card, taxonomy.List, taxonomy.Image, taxonomy.Unknown}
+
+// stopWords never tie a widget to a link target.
+var stopWords = map[string]bool{"page": true, "view": true, "screen": true, "the": true, "and": true, "for": true, "with": true, "your": true}
+
+const htmlStyle = `*{box-sizing:border-box}
+body{margin:0;font-family:Helvetica,Arial,sans-serif;background:#f0f0f0;color:#222}
+nav{position:sticky;top:0;z-index:1;display:flex;flex-wrap:wrap;gap:12px;align-items:center;padding:10px 16px;background:#333;color:#fff;font-size:14px}
+nav a{color:#9cf;text-decoration:none}
+nav a:hover{text-decoration:underline}
+nav .title{font-weight:bold;margin-right:auto}
+.board{position:relative;margin:24px auto;background:#fff;box-shadow:0 1px 4px rgba(0,0,0,.2)}
+.w{position:absolute;display:flex;flex-direction:column;padding:0 4px;line-height:1.2;white-space:nowrap;color:inherit;text-decoration:none}
+.ph{background-image:linear-gradient(to top right,transparent calc(50% - 1px),#999 50%,transparent calc(50% + 1px)),linear-gradient(to bottom right,transparent calc(50% - 1px),#999 50%,transparent calc(50% + 1px))}
+a.w{cursor:pointer}
+a.w:hover{outline:2px solid #1e90ff;outline-offset:1px}
+.edges{position:absolute;left:0;top:0;pointer-events:none}
+main.index{max-width:1100px;margin:0 auto;padding:24px}
+.story{background:#fff;margin:16px 0;padding:16px;box-shadow:0 1px 4px rgba(0,0,0,.2)}
+.story h2{margin:0 0 8px;font-size:18px}
+.story p{margin:0 0 12px;white-space:pre-line;color:#555}
+.views{display:flex;flex-wrap:wrap;gap:16px;list-style:none;margin:0;padding:0}
+.views a{display:flex;flex-direction:column;gap:6px;color:#06c;text-decoration:none}
+.views img{max-width:240px;max-height:180px;border:1px solid #ccc;background:#fff}
+`
+
+// HTMLPage renders a page as a standalone prototype screen: every widget an
+// absolutely positioned element, connectors in an SVG overlay, and a
+// navigation bar with the links and a way back to index. Links with a
+// Target also make the best matching widget clickable.
+func HTMLPage(p *ir.Page, title, index string, links []Link) []byte {
+	sc := newScene(p)
+	hot := hotspots(sc.Shapes, links)
+
+	var b bytes.Buffer
+	htmlHeader(&b, title)
+	fmt.Fprintf(&b, `<nav><a href="%s">☰ All views</a><span class="title">%s</span>`, esc(index), esc(title))
+	for _, l := range links {
+		fmt.Fprintf(&b, `<a href="%s">%s</a>`, esc(l.Href), esc(l.Label))
+	}
+	b.WriteString("</nav>\n")
+
+	fmt.Fprintf(&b, `<main class="board" style="width:%spx;height:%spx">`+"\n", num(sc.Width), num(sc.Height))
+	for i, s := range sc.Shapes {
+		writeElement(&b, s, hot[i])
+	}
+	if len(sc.Connectors) > 0 {
+		fmt.Fprintf(&b, `<svg class="edges" width="%s" height="%s" font-family="Helvetica, Arial, sans-serif">`, num(sc.Width), num(sc.Height))
+		arrowDefs(&b, sc.Connectors)
+		writeScene(&b, scene{Connectors: sc.Connectors})
+		b.WriteString("</svg>\n")
+	}
+	b.WriteString("</main>\n</body>\n</html>\n")
+	return b.Bytes()
+}
+
+// HTMLIndex renders a prototype's landing page: every story with its
+// narrative and links to its views.
+func HTMLIndex(title string, stories []IndexStory) []byte {
+	var b bytes.Buffer
+	htmlHeader(&b, title)
+	fmt.Fprintf(&b, `<nav><span class="title">%s</span></nav>`+"\n", esc(title))
+	b.WriteString(`<main class="index">` + "\n")
+	for _, st := range stories {
+		b.WriteString(`<section class="story">`)
+		fmt.Fprintf(&b, `<h2>%s · %s</h2>`, esc(st.ID), esc(st.Title))
+		if n := strings.TrimSpace(st.Narrative); n != "" {
+			fmt.Fprintf(&b, `<p>%s</p>`, esc(n))
+		}
+		if len(st.Views) == 0 {
+			b.WriteString(`<p><em>No views generated.</em></p>`)
+		}
+		b.WriteString(`<ul class="views">`)
+		for _, v := range st.Views {
+			fmt.Fprintf(&b, `<li><a href="%s">`, esc(v.Href))
+			if v.Image != "" {
+				fmt.Fprintf(&b, `<img src="%s" alt="">`, esc(v.Image))
+			}
+			fmt.Fprintf(&b, `<span>%s</span></a></li>`, esc(v.Name))
+		}
+		b.WriteString("</ul></section>\n")
+	}
+	b.WriteString("</main>\n</body>\n</html>\n")
+	return b.Bytes()
+}
+
+func htmlHeader(b *bytes.Buffer, title string) {
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(b, "<title>%s</title>\n<style>\n%s</style>\n</head>\n<body>\n", esc(title), htmlStyle)
+}
+
+// writeElement renders a shape as an absolutely positioned element, a link
+// when href is set.
+func writeElement(b *bytes.Buffer, s shape, href string) {
+	justify := "center"
+	switch s.VAlign {
+	case "top":
+		justify = "flex-start"
+	case "bottom":
+		justify = "flex-end"
+	}
+	css := []string{
+		"left:" + num(s.Box.X) + "px",
+		"top:" + num(s.Box.Y) + "px",
+		"width:" + num(s.Box.Width) + "px",
+		"height:" + num(s.Box.Height) + "px",
+		"font-size:" + num(s.FontSize) + "px",
+		"color:" + paint(s.FontColor),
+		"text-align:" + s.Align,
+		"justify-content:" + justify,
+	}
+	if s.Fill != "" {
+		css = append(css, "background-color:"+s.Fill)
+	}
+	if s.Stroke != "" {
+		css = append(css, fmt.Sprintf("border:%spx solid %s", num(s.StrokeWidth), s.Stroke))
+	}
+	switch {
+	case s.Ellipse:
+		css = append(css, "border-radius:50%")
+	case s.Radius > 0:
+		css = append(css, "border-radius:"+num(s.Radius)+"px")
+	}
+	if s.Bold {
+		css = append(css, "font-weight:bold")
+	}
+
+	class := "w"
+	if s.Placeholder {
+		class += " ph"
+	}
+	tag := "div"
+	if href != "" {
+		tag = "a"
+		fmt.Fprintf(b, `<a href="%s" `, esc(href))
+	} else {
+		b.WriteString("<div ")
+	}
+	fmt.Fprintf(b, `id="%s" class="%s" style="%s">`, esc(s.ID), class, esc(strings.Join(css, ";")))
+	for i, line := range s.Lines {
+		if i > 0 {
+			b.WriteString("<br>")
+		}
+		b.WriteString(esc(line))
+	}
+	fmt.Fprintf(b, "</%s>\n", tag)
+}
+
+// hotspots picks, for each link with a Target, the first clickable shape
+// whose text shares a word with it, preferring buttons and links. Each shape
+// carries at most one link.
+func hotspots(shapes []shape, links []Link) map[int]string {
+	hot := make(map[int]string)
+	for _, l := range links {
+		want := words(l.Target)
+		if len(want) == 0 {
+			continue
+		}
+		best, rank := -1, len(hotspotKinds)
+		for i, s := range shapes {
+			r := slices.Index(hotspotKinds, s.Kind)
+			if r < 0 || r >= rank || hot[i] != "" {
+				continue
+			}
+			if slices.ContainsFunc(words(strings.Join(s.Lines, " ")), func(w string) bool { return slices.Contains(want, w) }) {
+				best, rank = i, r
+			}
+		}
+		if best >= 0 {
+			hot[best] = l.Href
+		}
+	}
+	return hot
+}
+
+// words splits a name or label into lower-case words, breaking at
+// punctuation and camelCase, dropping stop words and plural s.
+func words(s string) []string {
+	var out []string
+	var cur []rune
+	flush := func() {
+		w := string(cur)
+		if !strings.HasSuffix(w, "ss") {
+			w = strings.TrimSuffix(w, "s")
+		}
+		if len(w) >= 3 && !stopWords[w] && !slices.Contains(out, w) {
+			out = append(out, w)
+		}
+		cur = cur[:0]
+	}
+	prev := ' '
+	for _, r := range s {
+		switch {
+		case unicode.IsUpper(r) && unicode.IsLower(prev):
+			flush()
+			cur = append(cur, unicode.ToLower(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			cur = append(cur, unicode.ToLower(r))
+		default:
+			flush()
+		}
+		prev = r
+	}
+	flush()
+	return out
+}
@@ -0,0 +1,75 @@
+package export
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"holoplan-cli/src/taxonomy"
+)
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{in: "Checkout", want: []string{"checkout"}},
+		{in: "OrderDetailsView", want: []string{"order", "detail"}},
+		{in: "Search results page", want: []string{"search", "result"}},
+		{in: "Go to the cart & pay", want: []string{"cart", "pay"}},
+		{in: "Step 2: Shipping-Address", want: []string{"step", "shipping", "address"}},
+		{in: "Items, items", want: []string{"item"}},
+		{in: "the page", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := words(tt.in); !slices.Equal(got, tt.want) {
+				t.Errorf("words(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHotspots(t *testing.T) {
+	el := func(kind taxonomy.Kind, label string) shape {
+		return shape{Kind: kind, Lines: []string{label}}
+	}
+	tests := []struct {
+		name   string
+		shapes []shape
+		links  []Link
+		want   map[int]string
+	}{
+		{
+			name:   "a button is preferred over an earlier card",
+			shapes: []shape{el(taxonomy.Card, "Your cart"), el(taxonomy.Button, "View cart")},
+			links:  []Link{{Href: "cart.html", Target: "Cart Page"}},
+			want:   map[int]string{1: "cart.html"},
+		},
+		{
+			name:   "text and inputs never carry links",
+			shapes: []shape{el(taxonomy.Text, "Checkout"), el(taxonomy.Input, "Checkout code")},
+			links:  []Link{{Href: "checkout.html", Target: "Checkout"}},
+			want:   map[int]string{},
+		},
+		{
+			name:   "each widget carries one link",
+			shapes: []shape{el(taxonomy.Button, "Order history"), el(taxonomy.Link, "Track order")},
+			links:  []Link{{Href: "history.html", Target: "OrderHistory"}, {Href: "track.html", Target: "Order tracking"}},
+			want:   map[int]string{0: "history.html", 1: "track.html"},
+		},
+		{
+			name:   "links without a target stay in the navigation bar",
+			shapes: []shape{el(taxonomy.Button, "Home")},
+			links:  []Link{{Label: "Home", Href: "index.html"}},
+			want:   map[int]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hotspots(tt.shapes, tt.links); !maps.Equal(got, tt.want) {
+				t.Errorf("hotspots = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
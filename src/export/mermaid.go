@@ -0,0 +1,77 @@
+// src/export/mermaid.go
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// FlowView is a view in a navigation flowchart.
+type FlowView struct {
+	ID    string // unique, letters, digits and underscores
+	Label string
+	Entry bool // reached directly rather than from another view
+	// Story marks a stand-in for a story whose views are not known yet,
+	// drawn as a dashed rounded node.
+	Story bool
+}
+
+// FlowEdge is a navigation step between two views, labelled with the stories
+// that take it.
+type FlowEdge struct {
+	From, To string // FlowView IDs
+	Label    string
+}
+
+// Mermaid renders a navigation graph as a Mermaid flowchart, left to right,
+// with entry views highlighted and story stand-ins dashed.
+func Mermaid(views []FlowView, edges []FlowEdge) []byte {
+	var b bytes.Buffer
+	b.WriteString("flowchart LR\n")
+	for _, v := range views {
+		if v.Story {
+			fmt.Fprintf(&b, "  %s([\"%s\"])\n", v.ID, mermaidText(v.Label))
+		} else {
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", v.ID, mermaidText(v.Label))
+		}
+	}
+	for _, e := range edges {
+		if e.Label == "" {
+			fmt.Fprintf(&b, "  %s --> %s\n", e.From, e.To)
+		} else {
+			fmt.Fprintf(&b, "  %s -->|\"%s\"| %s\n", e.From, mermaidText(e.Label), e.To)
+		}
+	}
+
+	var entries, stories []string
+	for _, v := range views {
+		if v.Entry {
+			entries = append(entries, v.ID)
+		}
+		if v.Story {
+			stories = append(stories, v.ID)
+		}
+	}
+	if len(entries) > 0 {
+		b.WriteString("  classDef entry fill:#dae8fc,stroke:#6c8ebf,stroke-width:2px\n")
+		fmt.Fprintf(&b, "  class %s entry\n", strings.Join(entries, ","))
+	}
+	if len(stories) > 0 {
+		b.WriteString("  classDef story fill:#fff,stroke:#999,stroke-dasharray:4 3,color:#666\n")
+		fmt.Fprintf(&b, "  class %s story\n", strings.Join(stories, ","))
+	}
+	return b.Bytes()
+}
+
+// MermaidMarkdown wraps a flowchart in a fenced block, which GitHub, GitLab
+// and most Markdown previews render as a diagram.
+func MermaidMarkdown(chart []byte) []byte {
+	return append(append([]byte("```mermaid\n"), chart...), "```\n"...)
+}
+
+// mermaidText keeps a label from ending its quotes; Mermaid reads entity
+// codes in quoted text.
+func mermaidText(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(s)
+}
@@ -0,0 +1,388 @@
+// src/export/png.go
+package export
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+
+	"holoplan-cli/src/ir"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+const (
+	// glyphSize is the font size basicfont's 7x13 face stands in for; labels
+	// are scaled from it to their own size.
+	glyphSize = 12
+	// maxTile is the widest a contact-sheet thumbnail is drawn, in pixels,
+	// and maxTileHeight the tallest.
+	maxTile       = 400
+	maxTileHeight = 4 * maxTile
+	// minTile keeps contact-sheet cells wide enough for a readable title.
+	minTile = 160
+	// sheetGap separates contact-sheet cells and surrounds the sheet.
+	sheetGap = 16
+	// maxPixels bounds any image drawn, so a stray element far off the page
+	// cannot make a preview allocate gigabytes: pages are drawn at a smaller
+	// scale to fit, and a contact sheet that would be larger is an error.
+	maxPixels = 40_000_000
+)
+
+var (
+	white     = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	black     = color.RGBA{0, 0, 0, 0xff}
+	lightGray = color.RGBA{0xcc, 0xcc, 0xcc, 0xff}
+	midGray   = color.RGBA{0x99, 0x99, 0x99, 0xff}
+	darkGray  = color.RGBA{0x33, 0x33, 0x33, 0xff}
+)
+
+// PNG renders one page as a PNG image at scale pixels per layout unit, or
+// smaller if that would exceed maxPixels. Text uses a built-in bitmap font,
+// so only Latin-1 characters are drawn.
+func PNG(p *ir.Page, scale float64) ([]byte, error) {
+	if scale <= 0 {
+		return nil, fmt.Errorf("invalid scale %v", scale)
+	}
+	return encodePNG(rasterize(newScene(p), scale))
+}
+
+// ContactSheet tiles every page of a document as a thumbnail under its name,
+// columns to a row. Pages are drawn at scale, shrunk further where that
+// would be wider than maxTile or taller than maxTileHeight.
+func ContactSheet(d *ir.Document, scale float64, columns int) ([]byte, error) {
+	if scale <= 0 {
+		return nil, fmt.Errorf("invalid scale %v", scale)
+	}
+	if len(d.Pages) == 0 {
+		return nil, fmt.Errorf("document has no pages")
+	}
+	columns = min(max(columns, 1), len(d.Pages))
+
+	thumbs := make([]*image.RGBA, len(d.Pages))
+	cellW := minTile
+	for i, p := range d.Pages {
+		sc := newScene(p)
+		s := min(scale, maxTile/(sc.Width+2*margin), maxTileHeight/(sc.Height+2*margin))
+		thumbs[i] = rasterize(sc, s)
+		cellW = max(cellW, thumbs[i].Bounds().Dx())
+	}
+
+	face := basicfont.Face7x13
+	titleH := face.Height + 6
+	var rowH []int
+	for i, t := range thumbs {
+		if i%columns == 0 {
+			rowH = append(rowH, 0)
+		}
+		rowH[len(rowH)-1] = max(rowH[len(rowH)-1], t.Bounds().Dy()+titleH)
+	}
+
+	width := sheetGap + columns*(cellW+sheetGap)
+	height := sheetGap
+	for _, h := range rowH {
+		height += h + sheetGap
+	}
+	if width*height > maxPixels {
+		return nil, fmt.Errorf("contact sheet of %d pages would be %dx%d pixels, over the %d limit", len(d.Pages), width, height, maxPixels)
+	}
+	sheet := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(sheet, sheet.Bounds(), image.NewUniform(color.RGBA{0xee, 0xee, 0xee, 0xff}), image.Point{}, draw.Src)
+
+	y := sheetGap
+	for i, t := range thumbs {
+		col := i % columns
+		if col == 0 && i > 0 {
+			y += rowH[i/columns-1] + sheetGap
+		}
+		x := sheetGap + col*(cellW+sheetGap)
+
+		title := []rune(d.Pages[i].Name)
+		if fit := cellW / face.Advance; len(title) > fit {
+			title = append(title[:max(fit-3, 0)], []rune("...")...)
+		}
+		dr := font.Drawer{Dst: sheet, Src: image.NewUniform(darkGray), Face: face, Dot: fixed.P(x, y+face.Ascent)}
+		dr.DrawString(string(title))
+
+		b := t.Bounds()
+		at := image.Pt(x, y+titleH)
+		draw.Draw(sheet, b.Add(at), t, b.Min, draw.Src)
+		outline(sheet, b.Add(at).Inset(-1), lightGray)
+	}
+	return encodePNG(sheet)
+}
+
+func encodePNG(img image.Image) ([]byte, error) {
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		return nil, fmt.Errorf("failed to encode PNG: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// outline draws a 1px border just inside r.
+func outline(img *image.RGBA, r image.Rectangle, c color.RGBA) {
+	src := image.NewUniform(c)
+	for _, side := range []image.Rectangle{
+		{r.Min, image.Pt(r.Max.X, r.Min.Y+1)},
+		{image.Pt(r.Min.X, r.Max.Y-1), r.Max},
+		{r.Min, image.Pt(r.Min.X+1, r.Max.Y)},
+		{image.Pt(r.Max.X-1, r.Min.Y), r.Max},
+	} {
+		draw.Draw(img, side, src, image.Point{}, draw.Src)
+	}
+}
+
+// raster draws a scene onto an image, mapping layout units to pixels.
+type raster struct {
+	img   *image.RGBA
+	scale float64
+	z     vector.Rasterizer
+}
+
+// rasterize draws a scene at scale, reduced as needed to fit maxPixels.
+func rasterize(sc scene, scale float64) *image.RGBA {
+	area := (sc.Width + 2*margin) * (sc.Height + 2*margin)
+	scale = min(scale, math.Sqrt(maxPixels/area))
+	w := int(math.Ceil((sc.Width + 2*margin) * scale))
+	h := int(math.Ceil((sc.Height + 2*margin) * scale))
+	if w*h > maxPixels {
+		// rounding up would overshoot the cap the scale was reduced to
+		w = int((sc.Width + 2*margin) * scale)
+		h = int((sc.Height + 2*margin) * scale)
+	}
+	r := &raster{img: image.NewRGBA(image.Rect(0, 0, max(w, 1), max(h, 1))), scale: scale}
+	draw.Draw(r.img, r.img.Bounds(), image.NewUniform(white), image.Point{}, draw.Src)
+
+	for _, s := range sc.Shapes {
+		r.shape(s)
+	}
+	for _, c := range sc.Connectors {
+		r.connector(c)
+	}
+	return r.img
+}
+
+// px maps a layout point to pixel space.
+func (r *raster) px(p ir.Point) ir.Point {
+	return ir.Point{X: (p.X + margin) * r.scale, Y: (p.Y + margin) * r.scale}
+}
+
+func (r *raster) shape(s shape) {
+	tl := r.px(ir.Point{X: s.Box.X, Y: s.Box.Y})
+	box := ir.Rect{X: tl.X, Y: tl.Y, Width: s.Box.Width * r.scale, Height: s.Box.Height * r.scale}
+
+	if box.Width > 0 && box.Height > 0 {
+		radius := s.Radius * r.scale
+		if c, ok := rgba(s.Fill); ok {
+			r.fill(c, contour(box, radius, s.Ellipse, 0))
+		}
+		if c, ok := rgba(s.Stroke); ok {
+			// A ring: the outer contour plus the inner one wound the other way
+			half := max(s.StrokeWidth*r.scale, 1) / 2
+			inner := contour(box, radius, s.Ellipse, half)
+			for i, j := 0, len(inner)-1; i < j; i, j = i+1, j-1 {
+				inner[i], inner[j] = inner[j], inner[i]
+			}
+			r.fill(c, contour(box, radius, s.Ellipse, -half), inner)
+		}
+		if s.Placeholder {
+			r.line(midGray, max(r.scale, 1), ir.Point{X: box.X, Y: box.Y}, ir.Point{X: box.X + box.Width, Y: box.Y + box.Height})
+			r.line(midGray, max(r.scale, 1), ir.Point{X: box.X + box.Width, Y: box.Y}, ir.Point{X: box.X, Y: box.Y + box.Height})
+		}
+	}
+
+	if len(s.Lines) == 0 {
+		return
+	}
+	c, ok := rgba(s.FontColor)
+	if !ok {
+		c = black
+	}
+	x, y := s.textOrigin()
+	for i, line := range s.Lines {
+		r.text(line, ir.Point{X: x, Y: y + float64(i)*s.FontSize*lineHeight}, s.FontSize, c, s.Align, s.Bold)
+	}
+}
+
+func (r *raster) connector(c connector) {
+	col, ok := rgba(c.Stroke)
+	if !ok {
+		col = black
+	}
+	pts := make([]ir.Point, len(c.Points))
+	for i, p := range c.Points {
+		pts[i] = r.px(p)
+	}
+	width := max(r.scale, 1)
+	for i := 1; i < len(pts); i++ {
+		r.line(col, width, pts[i-1], pts[i])
+	}
+
+	// Arrowhead at the target, matching the SVG marker
+	a, b := pts[len(pts)-2], pts[len(pts)-1]
+	dx, dy := b.X-a.X, b.Y-a.Y
+	if l := math.Hypot(dx, dy); l > 0 {
+		size := max(8*r.scale, 4)
+		ux, uy := dx/l*size, dy/l*size
+		r.fill(col, []ir.Point{
+			b,
+			{X: b.X - ux - uy/2, Y: b.Y - uy + ux/2},
+			{X: b.X - ux + uy/2, Y: b.Y - uy - ux/2},
+		})
+	}
+
+	if c.Label != "" {
+		mid := c.Points[len(c.Points)/2]
+		if len(c.Points)%2 == 0 {
+			p := c.Points[len(c.Points)/2-1]
+			mid = ir.Point{X: (p.X + mid.X) / 2, Y: (p.Y + mid.Y) / 2}
+		}
+		r.text(c.Label, ir.Point{X: mid.X, Y: mid.Y - 4}, 11, col, "center", false)
+	}
+}
+
+// line draws a straight segment width pixels wide between two pixel points.
+func (r *raster) line(c color.RGBA, width float64, a, b ir.Point) {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	l := math.Hypot(dx, dy)
+	if l == 0 {
+		return
+	}
+	nx, ny := -dy/l*width/2, dx/l*width/2
+	r.fill(c, []ir.Point{
+		{X: a.X + nx, Y: a.Y + ny},
+		{X: b.X + nx, Y: b.Y + ny},
+		{X: b.X - nx, Y: b.Y - ny},
+		{X: a.X - nx, Y: a.Y - ny},
+	})
+}
+
+// fill paints the area enclosed by the contours, in pixel coordinates,
+// rasterizing only their bounding box.
+func (r *raster) fill(c color.RGBA, contours ...[]ir.Point) {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, ct := range contours {
+		for _, p := range ct {
+			minX, minY = min(minX, p.X), min(minY, p.Y)
+			maxX, maxY = max(maxX, p.X), max(maxY, p.Y)
+		}
+	}
+	bounds := image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Ceil(maxX)), int(math.Ceil(maxY)))
+	clip := bounds.Intersect(r.img.Bounds())
+	if clip.Empty() {
+		return
+	}
+
+	// The mask starts at the clipped corner; the rasterizer clips the rest
+	ox, oy := float64(clip.Min.X), float64(clip.Min.Y)
+	r.z.Reset(clip.Dx(), clip.Dy())
+	for _, ct := range contours {
+		if len(ct) < 3 {
+			continue
+		}
+		r.z.MoveTo(float32(ct[0].X-ox), float32(ct[0].Y-oy))
+		for _, p := range ct[1:] {
+			r.z.LineTo(float32(p.X-ox), float32(p.Y-oy))
+		}
+		r.z.ClosePath()
+	}
+	r.z.Draw(r.img, clip, image.NewUniform(c), image.Point{})
+}
+
+// text draws one line of a label with its baseline at the layout point at,
+// anchored left, center or right.
+func (r *raster) text(s string, at ir.Point, size float64, c color.RGBA, align string, bold bool) {
+	face := basicfont.Face7x13
+	runes := len([]rune(s))
+	if runes == 0 {
+		return
+	}
+
+	// Draw at the face's native size, then scale into place
+	w := runes * face.Advance
+	if bold {
+		w++
+	}
+	glyphs := image.NewRGBA(image.Rect(0, 0, w, face.Height))
+	dr := font.Drawer{Dst: glyphs, Src: image.NewUniform(c), Face: face, Dot: fixed.P(0, face.Ascent)}
+	dr.DrawString(s)
+	if bold {
+		dr.Dot = fixed.P(1, face.Ascent)
+		dr.DrawString(s)
+	}
+
+	k := cmp.Or(size, glyphSize) / glyphSize * r.scale
+	p := r.px(at)
+	x := p.X
+	switch align {
+	case "center":
+		x -= float64(w) * k / 2
+	case "right":
+		x -= float64(w) * k
+	}
+	top := p.Y - float64(face.Ascent)*k
+	dst := image.Rect(int(math.Round(x)), int(math.Round(top)),
+		int(math.Round(x+float64(w)*k)), int(math.Round(top+float64(face.Height)*k)))
+	if dst.Empty() {
+		return
+	}
+	xdraw.CatmullRom.Scale(r.img, dst, glyphs, glyphs.Bounds(), xdraw.Over, nil)
+}
+
+// contour outlines a box in pixel space as a polygon, clockwise, inset by d
+// (outset when negative): a rounded rectangle, or an ellipse.
+func contour(box ir.Rect, radius float64, ellipse bool, d float64) []ir.Point {
+	x, y := box.X+d, box.Y+d
+	w, h := max(box.Width-2*d, 0), max(box.Height-2*d, 0)
+
+	if ellipse {
+		const steps = 48
+		pts := make([]ir.Point, steps)
+		for i := range pts {
+			a := 2 * math.Pi * float64(i) / steps
+			pts[i] = ir.Point{X: x + w/2 + w/2*math.Cos(a), Y: y + h/2 + h/2*math.Sin(a)}
+		}
+		return pts
+	}
+
+	radius = min(max(radius-d, 0), w/2, h/2)
+	if radius < 0.5 {
+		return []ir.Point{{X: x, Y: y}, {X: x + w, Y: y}, {X: x + w, Y: y + h}, {X: x, Y: y + h}}
+	}
+	const steps = 8
+	var pts []ir.Point
+	corners := []ir.Point{
+		{X: x + w - radius, Y: y + radius},
+		{X: x + w - radius, Y: y + h - radius},
+		{X: x + radius, Y: y + h - radius},
+		{X: x + radius, Y: y + radius},
+	}
+	for i, ctr := range corners {
+		start := -math.Pi/2 + float64(i)*math.Pi/2
+		for j := 0; j <= steps; j++ {
+			a := start + math.Pi/2*float64(j)/steps
+			pts = append(pts, ir.Point{X: ctr.X + radius*math.Cos(a), Y: ctr.Y + radius*math.Sin(a)})
+		}
+	}
+	return pts
+}
+
+// rgba parses a resolved "#rrggbb" color; "" and unparseable colors are none.
+func rgba(hex string) (color.RGBA, bool) {
+	r, g, b, ok := ir.ParseHex(hex)
+	if !ok {
+		return color.RGBA{}, false
+	}
+	return color.RGBA{r, g, b, 0xff}, true
+}
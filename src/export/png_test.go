@@ -0,0 +1,83 @@
+package export
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"math"
+	"strings"
+	"testing"
+
+	"holoplan-cli/src/ir"
+)
+
+func decodePNG(t *testing.T, data []byte, err error) image.Image {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("not a PNG: %v", err)
+	}
+	return img
+}
+
+func TestPNGSize(t *testing.T) {
+	tests := []struct {
+		name  string
+		cells []string
+		scale float64
+	}{
+		{name: "small page at double scale", cells: []string{"Save:0,0,120,40", "Cancel:140,0,120,40"}, scale: 2},
+		{name: "stray element is drawn smaller", cells: []string{"Save:0,0,120,40", "Lost:90000,90000,10,10"}, scale: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := drawioPage(t, tt.cells...)
+			data, err := PNG(p, tt.scale)
+			b := decodePNG(t, data, err).Bounds()
+			if b.Dx()*b.Dy() > maxPixels {
+				t.Errorf("image is %dx%d, over the %d pixel limit", b.Dx(), b.Dy(), maxPixels)
+			}
+			sc := newScene(p)
+			if w := int(math.Ceil((sc.Width + 2*margin) * tt.scale)); w*w <= maxPixels && b.Dx() != w {
+				t.Errorf("width = %d, want %d", b.Dx(), w)
+			}
+		})
+	}
+
+	if _, err := PNG(drawioPage(t, "Save:0,0,120,40"), 0); err == nil {
+		t.Error("scale 0 accepted")
+	}
+}
+
+func TestContactSheet(t *testing.T) {
+	small := drawioPage(t, "Save:0,0,120,40")
+	small.Name = "Small"
+	wide := drawioPage(t, "Banner:0,0,4000,100")
+	wide.Name = strings.Repeat("A very long page name ", 5)
+
+	tests := []struct {
+		name      string
+		pages     []*ir.Page
+		columns   int
+		wantWidth int
+	}{
+		{name: "one column", pages: []*ir.Page{small, small, small}, columns: 1, wantWidth: sheetGap + minTile + sheetGap},
+		{name: "columns capped at the page count", pages: []*ir.Page{small, small}, columns: 4, wantWidth: sheetGap + 2*(minTile+sheetGap)},
+		{name: "wide pages shrink to the tile width", pages: []*ir.Page{small, wide}, columns: 2, wantWidth: sheetGap + 2*(maxTile+sheetGap)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := ContactSheet(&ir.Document{Pages: tt.pages}, 1, tt.columns)
+			if b := decodePNG(t, data, err).Bounds(); b.Dx() != tt.wantWidth {
+				t.Errorf("width = %d, want %d", b.Dx(), tt.wantWidth)
+			}
+		})
+	}
+
+	if _, err := ContactSheet(&ir.Document{}, 1, 2); err == nil {
+		t.Error("empty document accepted")
+	}
+}
@@ -0,0 +1,291 @@
+// src/export/salt.go
+package export
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"holoplan-cli/src/ir"
+	"holoplan-cli/src/taxonomy"
+)
+
+// saltNode is a widget in the containment tree Salt's nested grids need:
+// every widget lies inside the smallest other widget that contains it.
+type saltNode struct {
+	Kind     taxonomy.Kind
+	Box      ir.Rect
+	Text     string
+	Children []*saltNode
+}
+
+// Salt renders a page as a PlantUML Salt wireframe. Widgets are nested by
+// containment and laid out in rows by position; each kind maps to its Salt
+// control: buttons, text fields, checkboxes, radios, dropdowns, tabs, menus,
+// tables, lists and group boxes.
+func Salt(p *ir.Page, title string) []byte {
+	var b bytes.Buffer
+	writeSalt(&b, p, title)
+	return b.Bytes()
+}
+
+// SaltDocument renders every page of a document as its own @startsalt
+// diagram in one file, titled by page name.
+func SaltDocument(d *ir.Document) []byte {
+	var b bytes.Buffer
+	for i, p := range d.Pages {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		writeSalt(&b, p, p.Name)
+	}
+	return b.Bytes()
+}
+
+func writeSalt(b *bytes.Buffer, p *ir.Page, title string) {
+	b.WriteString("@startsalt\n")
+	if title != "" {
+		fmt.Fprintf(b, "title %s\n", title)
+	}
+	b.WriteString("{+\n")
+	for _, line := range saltGrid(saltTree(p), 1) {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("}\n@endsalt\n")
+}
+
+// saltTree nests the page's widgets by containment, ignoring how the source
+// format happened to parent them.
+func saltTree(p *ir.Page) []*saltNode {
+	var nodes []*saltNode
+	p.Walk(func(w *ir.Widget, abs ir.Rect, _ *ir.Widget) {
+		nodes = append(nodes, &saltNode{Kind: w.Kind, Box: abs, Text: strings.Join(ir.Lines(w.Label), " ")})
+	})
+
+	// Larger widgets first, so a widget's candidate parents precede it
+	order := slices.Clone(nodes)
+	slices.SortStableFunc(order, func(a, b *saltNode) int {
+		return cmp.Compare(b.Box.Width*b.Box.Height, a.Box.Width*a.Box.Height)
+	})
+
+	var roots []*saltNode
+	for i, n := range order {
+		var parent *saltNode
+		for _, c := range order[:i] {
+			if c.Kind != taxonomy.Text && contains(c.Box, n.Box) &&
+				(parent == nil || c.Box.Width*c.Box.Height < parent.Box.Width*parent.Box.Height) {
+				parent = c
+			}
+		}
+		if parent != nil {
+			parent.Children = append(parent.Children, n)
+		} else {
+			roots = append(roots, n)
+		}
+	}
+	return roots
+}
+
+// contains reports whether inner lies within outer, allowing a pixel of
+// rounding.
+func contains(outer, inner ir.Rect) bool {
+	const tol = 1
+	return inner.X >= outer.X-tol && inner.Y >= outer.Y-tol &&
+		inner.X+inner.Width <= outer.X+outer.Width+tol &&
+		inner.Y+inner.Height <= outer.Y+outer.Height+tol
+}
+
+// saltGrid lays nodes out as rows of the enclosing grid by recursive XY-cut:
+// nodes split into bands at horizontal gaps, each band a row, and a band into
+// columns at vertical gaps, each column a cell of that row.
+func saltGrid(nodes []*saltNode, depth int) []string {
+	indent := strings.Repeat("  ", depth)
+	if bands := cut(nodes, func(r ir.Rect) (float64, float64) { return r.Y, r.Y + r.Height }); len(bands) > 1 {
+		var lines []string
+		for _, band := range bands {
+			lines = append(lines, saltGrid(band, depth)...)
+		}
+		return lines
+	}
+
+	cols := cut(nodes, func(r ir.Rect) (float64, float64) { return r.X, r.X + r.Width })
+	if len(cols) == 1 && len(nodes) > 1 {
+		// Overlapping widgets no gap separates: one row each, top to bottom
+		sorted := slices.Clone(nodes)
+		slices.SortStableFunc(sorted, func(a, b *saltNode) int {
+			return cmp.Or(cmp.Compare(a.Box.Y, b.Box.Y), cmp.Compare(a.Box.X, b.Box.X))
+		})
+		var lines []string
+		for _, n := range sorted {
+			lines = append(lines, saltGrid([]*saltNode{n}, depth)...)
+		}
+		return lines
+	}
+
+	var cells [][]string
+	for _, col := range cols {
+		var cell []string
+		if len(col) == 1 {
+			cell = saltCell(col[0], depth+1)
+		} else if rows := saltGrid(col, depth+1); len(rows) > 0 {
+			cell = append(append([]string{"{"}, rows...), indent+"}")
+		}
+		if len(cell) > 0 {
+			cells = append(cells, cell)
+		}
+	}
+	if len(cells) == 0 {
+		return nil
+	}
+	if len(cells) == 1 {
+		return append([]string{indent + cells[0][0]}, cells[0][1:]...)
+	}
+
+	// Several columns make a one-row grid of their own, so rows with
+	// different numbers of cells do not share columns
+	var lines []string
+	line := indent + "{ "
+	for i, cell := range cells {
+		if i > 0 {
+			line += " | "
+		}
+		line += cell[0]
+		for _, l := range cell[1:] {
+			lines = append(lines, line)
+			line = l
+		}
+	}
+	return append(lines, line+" }")
+}
+
+// cut splits nodes into groups separated by gaps along one axis, in order.
+// Overlaps of a pixel or two still count as gaps.
+func cut(nodes []*saltNode, span func(ir.Rect) (float64, float64)) [][]*saltNode {
+	sorted := slices.Clone(nodes)
+	slices.SortStableFunc(sorted, func(a, b *saltNode) int {
+		sa, _ := span(a.Box)
+		sb, _ := span(b.Box)
+		return cmp.Compare(sa, sb)
+	})
+
+	var groups [][]*saltNode
+	end := 0.0
+	for _, n := range sorted {
+		start, stop := span(n.Box)
+		if len(groups) == 0 || start >= end-2 {
+			groups = append(groups, []*saltNode{n})
+			end = stop
+			continue
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], n)
+		end = max(end, stop)
+	}
+	return groups
+}
+
+// saltCell renders one node, over several lines when it is a container; the
+// first line is unindented so it can follow a cell separator.
+func saltCell(n *saltNode, depth int) []string {
+	text := saltText(n.Text)
+	indent := strings.Repeat("  ", depth-1)
+	rows := saltGrid(n.Children, depth)
+	block := func(open string) []string {
+		return append(append([]string{open}, rows...), indent+"}")
+	}
+
+	if len(rows) > 0 {
+		items := childTexts(n)
+		switch {
+		case n.Kind == taxonomy.Tabs && items != nil:
+			return []string{saltTabs(items)}
+		case n.Kind == taxonomy.Navbar && items != nil:
+			return []string{"{* " + strings.Join(items, " | ") + " }"}
+		case text != "":
+			return block(`{^"` + text + `"`)
+		default:
+			return block("{+")
+		}
+	}
+
+	if text == "" && n.Kind != taxonomy.Image {
+		return nil // decoration
+	}
+	switch n.Kind {
+	case taxonomy.Button, taxonomy.FAB:
+		return []string{"[" + text + "]"}
+	case taxonomy.Input, taxonomy.Search:
+		// Pad the field to roughly its width in characters
+		width := max(int(n.Box.Width/(ir.DefaultFontSize*charWidth)), len([]rune(text))+3)
+		return []string{`"` + text + strings.Repeat(" ", width-len([]rune(text))) + `"`}
+	case taxonomy.Checkbox:
+		return []string{"[] " + text}
+	case taxonomy.Radio:
+		return []string{"() " + text}
+	case taxonomy.Dropdown:
+		return []string{"^" + text + "^"}
+	case taxonomy.Link:
+		return []string{"<u>" + text + "</u>"}
+	case taxonomy.Image:
+		return []string{strings.TrimSpace("<&image> " + text)}
+	case taxonomy.Header:
+		return []string{"<b>" + text + "</b>"}
+	case taxonomy.Tabs:
+		return []string{saltTabs(splitItems(n.Text))}
+	case taxonomy.Navbar:
+		return []string{"{* " + strings.Join(splitItems(n.Text), " | ") + " }"}
+	case taxonomy.Table:
+		return []string{"{#", indent + "  " + strings.Join(splitItems(n.Text), " | "), indent + "}"}
+	case taxonomy.List:
+		lines := []string{"{SI"}
+		for _, item := range splitItems(n.Text) {
+			lines = append(lines, indent+"  "+item)
+		}
+		return append(lines, indent+"}")
+	case taxonomy.Text, taxonomy.Breadcrumb, taxonomy.Unknown:
+		return []string{text}
+	}
+	// Cards, forms, footers and other regions with nothing inside
+	return []string{"{+ " + text + " }"}
+}
+
+// childTexts returns the labels of a node's children when they are all
+// plain leaves, the items of a tab bar or menu; nil otherwise.
+func childTexts(n *saltNode) []string {
+	var items []string
+	for _, c := range n.Children {
+		if len(c.Children) > 0 || c.Text == "" {
+			return nil
+		}
+		items = append(items, saltText(c.Text))
+	}
+	return items
+}
+
+// saltTabs renders a tab bar with the first tab selected.
+func saltTabs(items []string) string {
+	if len(items) == 0 {
+		return ""
+	}
+	return "{/ <b>" + strings.Join(items, " | ") + " }"
+}
+
+// splitItems breaks a label like "Home | Shop | Cart" or "Name, Price" into
+// items; a label without separators is a single item.
+func splitItems(label string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(label, func(r rune) bool { return strings.ContainsRune("|,;/•·", r) }) {
+		if item = saltText(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// saltText keeps a label from being read as Salt syntax.
+func saltText(s string) string {
+	return strings.TrimSpace(strings.NewReplacer(
+		"|", "/", "{", "(", "}", ")", "[", "(", "]", ")", `"`, "'", "^", " ", "\t", " ",
+	).Replace(s))
+}
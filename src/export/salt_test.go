@@ -0,0 +1,79 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"holoplan-cli/src/ir"
+)
+
+// drawioPage parses vertices given as "label:x,y,w,h", all on the layer, so
+// that any nesting comes from saltTree's containment.
+func drawioPage(t *testing.T, cells ...string) *ir.Page {
+	t.Helper()
+	var b strings.Builder
+	b.WriteString(`<mxGraphModel><root><mxCell id="0"/><mxCell id="1" parent="0"/>`)
+	for i, c := range cells {
+		label, geo, _ := strings.Cut(c, ":")
+		var x, y, w, h float64
+		fmt.Sscanf(geo, "%g,%g,%g,%g", &x, &y, &w, &h)
+		fmt.Fprintf(&b, `<mxCell id="c%d" value="%s" style="rounded=0;" vertex="1" parent="1"><mxGeometry x="%g" y="%g" width="%g" height="%g" as="geometry"/></mxCell>`,
+			i, label, x, y, w, h)
+	}
+	b.WriteString(`</root></mxGraphModel>`)
+	doc, err := ir.FromDrawio(b.String())
+	if err != nil {
+		t.Fatalf("FromDrawio: %v", err)
+	}
+	return doc.Pages[0]
+}
+
+func TestSaltXYCut(t *testing.T) {
+	tests := []struct {
+		name  string
+		cells []string
+		want  []string // lines between "{+" and the closing "}"
+	}{
+		{
+			name:  "stacked widgets are rows",
+			cells: []string{"First:0,0,200,30", "Second:0,50,200,30", "Third:0,100,200,30"},
+			want:  []string{"  First", "  Second", "  Third"},
+		},
+		{
+			name:  "a vertical gap splits a row into cells",
+			cells: []string{"Left:0,0,100,30", "Right:200,0,100,30"},
+			want:  []string{"  { Left | Right }"},
+		},
+		{
+			name:  "rows are cut before columns",
+			cells: []string{"Right:200,0,100,30", "Left:0,0,100,30", "Below:0,60,300,30"},
+			want:  []string{"  { Left | Right }", "  Below"},
+		},
+		{
+			name:  "a column spanning two rows becomes a nested grid",
+			cells: []string{"Side:0,0,100,200", "Top:200,0,100,30", "Bottom:200,100,100,30"},
+			want:  []string{"  { Side | {", "    Top", "    Bottom", "  } }"},
+		},
+		{
+			name:  "contained widgets nest inside their container",
+			cells: []string{"Card:0,0,400,200", "Inside:20,20,100,30", "Outside:0,300,100,30"},
+			want:  []string{`  {^"Card"`, "    Inside", "  }", "  Outside"},
+		},
+		{
+			name:  "overlapping widgets fall back to one row each",
+			cells: []string{"Under:0,0,200,50", "Over:100,20,200,50"},
+			want:  []string{"  Under", "  Over"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := string(Salt(drawioPage(t, tt.cells...), ""))
+			want := "@startsalt\n{+\n" + strings.Join(tt.want, "\n") + "\n}\n@endsalt\n"
+			if out != want {
+				t.Errorf("got\n%s\nwant\n%s", out, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+// src/export/scene.go
+
+// Package export renders and converts wireframes from the format-neutral IR
+// into preview images and the formats other tools read.
+package export
+
+import (
+	"cmp"
+	"math"
+	"strconv"
+	"strings"
+
+	"holoplan-cli/src/ir"
+	"holoplan-cli/src/taxonomy"
+)
+
+const (
+	// charWidth is the average glyph width as a fraction of the font size,
+	// the same estimate the validator's text-fit rule uses.
+	charWidth = 0.6
+	// lineHeight is the line height as a multiple of the font size.
+	lineHeight = 1.2
+	// textPadding is the horizontal room kept free inside a shape's edges.
+	textPadding = 4
+	// margin surrounds the drawing in rendered previews.
+	margin = 10
+)
+
+// shape is a widget resolved for drawing: absolute bounds, concrete colors
+// ("" means none) and its label wrapped to fit.
+type shape struct {
+	ID          string
+	Kind        taxonomy.Kind
+	Box         ir.Rect
+	Fill        string
+	Stroke      string
+	StrokeWidth float64
+	Radius      float64
+	Ellipse     bool
+	Placeholder bool // image without a source, drawn as a crossed box
+	Lines       []string
+	FontSize    float64
+	FontColor   string
+	Bold        bool
+	Align       string // "left", "center" or "right"
+	VAlign      string // "top", "middle" or "bottom"
+}
+
+// connector is an edge resolved to absolute points. Source and Target are
+// the shapes its ends are attached to, if any.
+type connector struct {
+	ID             string
+	Source, Target string
+	Points         []ir.Point
+	Label          string
+	Stroke         string
+}
+
+// scene is everything needed to draw one page.
+type scene struct {
+	Width, Height float64
+	Shapes        []shape
+	Connectors    []connector
+}
+
+// newScene resolves a page for drawing, in drawing order.
+func newScene(p *ir.Page) scene {
+	var sc scene
+	sc.Width, sc.Height = p.Extent()
+
+	boxes := make(map[string]ir.Rect)
+	for _, f := range p.Frames {
+		// Figma frames may paint a background; Draw.io layers never do
+		if f.Style.Fill != "" && f.Style.Fill != "none" {
+			sc.Shapes = append(sc.Shapes, shape{ID: f.ID, Box: f.Bounds, Fill: f.Style.Fill})
+		}
+		f.Walk(func(w *ir.Widget, abs ir.Rect, _ *ir.Widget) {
+			boxes[w.ID] = abs
+			sc.Shapes = append(sc.Shapes, newShape(w, abs))
+			// Content past the page edge is still drawn
+			sc.Width = max(sc.Width, abs.X+abs.Width)
+			sc.Height = max(sc.Height, abs.Y+abs.Height)
+		})
+	}
+
+	for _, f := range p.Frames {
+		for _, e := range f.Edges {
+			ox, oy := f.Bounds.X, f.Bounds.Y
+			if parent, ok := boxes[e.Parent]; ok {
+				ox, oy = parent.X, parent.Y
+			}
+			end := func(id string, pt *ir.Point) (ir.Point, bool) {
+				if b, ok := boxes[id]; ok {
+					return ir.Point{X: b.X + b.Width/2, Y: b.Y + b.Height/2}, true
+				}
+				if pt != nil {
+					return ir.Point{X: pt.X + ox, Y: pt.Y + oy}, true
+				}
+				return ir.Point{}, false
+			}
+			from, ok1 := end(e.Source, e.SourcePoint)
+			to, ok2 := end(e.Target, e.TargetPoint)
+			if !ok1 || !ok2 {
+				continue
+			}
+
+			c := connector{ID: e.ID, Label: ir.PlainText(e.Label), Stroke: cmp.Or(e.Style.Stroke, "#000000")}
+			if c.Stroke == "none" {
+				continue
+			}
+			c.Points = append(c.Points, from)
+			for _, wp := range e.Waypoints {
+				c.Points = append(c.Points, ir.Point{X: wp.X + ox, Y: wp.Y + oy})
+			}
+			c.Points = append(c.Points, to)
+
+			// Start and end on the shapes' borders rather than their centers
+			if b, ok := boxes[e.Source]; ok {
+				c.Source = e.Source
+				c.Points[0] = clipToBox(b, c.Points[1])
+			}
+			if b, ok := boxes[e.Target]; ok {
+				c.Target = e.Target
+				n := len(c.Points)
+				c.Points[n-1] = clipToBox(b, c.Points[n-2])
+			}
+			sc.Connectors = append(sc.Connectors, c)
+		}
+	}
+	return sc
+}
+
+func newShape(w *ir.Widget, abs ir.Rect) shape {
+	styles := taxonomy.ParseStyle(w.Style.Raw)
+	figma := w.Meta["figma.type"] != ""
+
+	s := shape{
+		ID:          w.ID,
+		Kind:        w.Kind,
+		Box:         abs,
+		Fill:        w.Style.Fill,
+		Stroke:      w.Style.Stroke,
+		StrokeWidth: cmp.Or(w.Style.StrokeWidth, 1),
+		FontSize:    cmp.Or(w.Style.FontSize, ir.DefaultFontSize),
+		FontColor:   cmp.Or(w.Style.FontColor, "#000000"),
+		Bold:        w.Style.Bold,
+		Align:       "center",
+		VAlign:      cmp.Or(styles["verticalAlign"], "middle"),
+	}
+
+	// Draw.io shapes default to a white fill and black stroke; Figma nodes
+	// are transparent unless painted
+	if s.Fill == "" && !figma {
+		s.Fill = "#ffffff"
+	}
+	if s.Stroke == "" && !figma {
+		s.Stroke = "#000000"
+	}
+	if s.Fill == "none" {
+		s.Fill = ""
+	}
+	if s.Stroke == "none" {
+		s.Stroke = ""
+	}
+
+	_, s.Ellipse = styles["ellipse"]
+	s.Ellipse = s.Ellipse || styles["shape"] == "ellipse"
+	s.Placeholder = w.Kind == taxonomy.Image && styles["image"] == ""
+
+	switch {
+	case w.Style.Radius > 0:
+		s.Radius = w.Style.Radius
+	case w.Style.Rounded:
+		// Draw.io's arcSize is a percentage of the shorter side, 15 by default
+		pct, err := strconv.ParseFloat(styles["arcSize"], 64)
+		if err != nil || pct <= 0 {
+			pct = 15
+		}
+		s.Radius = min(abs.Width, abs.Height) * pct / 100
+	}
+
+	switch align := strings.ToLower(cmp.Or(styles["align"], w.Meta["figma.textAlignHorizontal"])); {
+	case align == "left" || align == "right" || align == "center":
+		s.Align = align
+	case figma && w.Meta["figma.type"] == "TEXT":
+		s.Align = "left" // Figma's default for text
+	}
+	if figma && w.Meta["figma.type"] == "TEXT" && styles["verticalAlign"] == "" {
+		s.VAlign = "top"
+	}
+
+	if styles["noLabel"] != "1" {
+		s.Lines = wrap(ir.Lines(w.Label), abs.Width-2*textPadding, s.FontSize)
+	}
+	return s
+}
+
+// wrap breaks lines at spaces so that each fits width at the given font
+// size. A single word wider than width keeps its own line.
+func wrap(lines []string, width, fontSize float64) []string {
+	perLine := int(width / (fontSize * charWidth))
+	if perLine < 1 {
+		return lines
+	}
+
+	var out []string
+	for _, line := range lines {
+		words := strings.Fields(line)
+		cur := ""
+		for _, word := range words {
+			switch {
+			case cur == "":
+				cur = word
+			case len([]rune(cur))+1+len([]rune(word)) <= perLine:
+				cur += " " + word
+			default:
+				out = append(out, cur)
+				cur = word
+			}
+		}
+		if cur != "" {
+			out = append(out, cur)
+		}
+	}
+	return out
+}
+
+// textOrigin returns the baseline of the first label line and the x anchor
+// for the shape's alignment.
+func (s shape) textOrigin() (x, y float64) {
+	lh := s.FontSize * lineHeight
+	block := lh * float64(len(s.Lines))
+
+	switch s.Align {
+	case "left":
+		x = s.Box.X + textPadding
+	case "right":
+		x = s.Box.X + s.Box.Width - textPadding
+	default:
+		x = s.Box.X + s.Box.Width/2
+	}
+
+	switch s.VAlign {
+	case "top":
+		y = s.Box.Y + textPadding
+	case "bottom":
+		y = s.Box.Y + s.Box.Height - textPadding - block
+	default:
+		y = s.Box.Y + (s.Box.Height-block)/2
+	}
+	// Baseline sits about 0.8em below the top of the line box
+	return x, y + (lh-s.FontSize)/2 + s.FontSize*0.8
+}
+
+// clipToBox returns where the segment from b's center towards p leaves b.
+func clipToBox(b ir.Rect, p ir.Point) ir.Point {
+	cx, cy := b.X+b.Width/2, b.Y+b.Height/2
+	dx, dy := p.X-cx, p.Y-cy
+	if dx == 0 && dy == 0 {
+		return ir.Point{X: cx, Y: cy}
+	}
+	t := math.Inf(1)
+	if dx != 0 {
+		t = min(t, (b.Width/2)/math.Abs(dx))
+	}
+	if dy != 0 {
+		t = min(t, (b.Height/2)/math.Abs(dy))
+	}
+	t = min(t, 1)
+	return ir.Point{X: cx + dx*t, Y: cy + dy*t}
+}
@@ -0,0 +1,183 @@
+// src/export/svg.go
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"holoplan-cli/src/ir"
+)
+
+// pageGap separates the pages of a multi-page SVG, leaving room for titles.
+const pageGap = 40
+
+// SVG renders one page as a standalone SVG image.
+func SVG(p *ir.Page) []byte {
+	sc := newScene(p)
+	w, h := sc.Width+2*margin, sc.Height+2*margin
+
+	var b bytes.Buffer
+	svgHeader(&b, w, h, sc.Connectors)
+	fmt.Fprintf(&b, `<g transform="translate(%s,%s)">`+"\n", num(margin), num(margin))
+	writeScene(&b, sc)
+	b.WriteString("</g>\n</svg>\n")
+	return b.Bytes()
+}
+
+// SVGDocument renders every page of a document stacked vertically, each under
+// its name, as a single SVG image.
+func SVGDocument(d *ir.Document) []byte {
+	var scenes []scene
+	var connectors []connector
+	width, height := 0.0, 0.0
+	for _, p := range d.Pages {
+		sc := newScene(p)
+		scenes = append(scenes, sc)
+		connectors = append(connectors, sc.Connectors...)
+		width = max(width, sc.Width)
+		height += sc.Height + pageGap
+	}
+
+	var b bytes.Buffer
+	svgHeader(&b, width+2*margin, height+margin, connectors)
+	y := 0.0
+	for i, sc := range scenes {
+		fmt.Fprintf(&b, `<text x="%s" y="%s" font-size="16" font-weight="bold" fill="#333333">%s</text>`+"\n",
+			num(margin), num(y+pageGap-14), esc(d.Pages[i].Name))
+		fmt.Fprintf(&b, `<g transform="translate(%s,%s)">`+"\n", num(margin), num(y+pageGap))
+		fmt.Fprintf(&b, `<rect x="0" y="0" width="%s" height="%s" fill="none" stroke="#cccccc" stroke-dasharray="4 4"/>`+"\n",
+			num(sc.Width), num(sc.Height))
+		writeScene(&b, sc)
+		b.WriteString("</g>\n")
+		y += sc.Height + pageGap
+	}
+	b.WriteString("</svg>\n")
+	return b.Bytes()
+}
+
+func svgHeader(b *bytes.Buffer, w, h float64, connectors []connector) {
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s" font-family="Helvetica, Arial, sans-serif">`+"\n",
+		num(w), num(h), num(w), num(h))
+	arrowDefs(b, connectors)
+	fmt.Fprintf(b, `<rect width="100%%" height="100%%" fill="#ffffff"/>`+"\n")
+}
+
+// arrowDefs writes the arrowhead markers for the connectors: one per stroke
+// color, filled with it, since SVG 2's fill="context-stroke" is ignored by
+// librsvg, Inkscape and many image previews.
+func arrowDefs(b *bytes.Buffer, connectors []connector) {
+	seen := make(map[string]bool)
+	for _, c := range connectors {
+		id := arrowID(c.Stroke)
+		if seen[id] {
+			continue
+		}
+		if len(seen) == 0 {
+			b.WriteString("<defs>")
+		}
+		seen[id] = true
+		fmt.Fprintf(b, `<marker id="%s" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="8" markerHeight="8" orient="auto-start-reverse"><path d="M0,0 L10,5 L0,10 z" fill="%s"/></marker>`,
+			id, paint(c.Stroke))
+	}
+	if len(seen) > 0 {
+		b.WriteString("</defs>\n")
+	}
+}
+
+// arrowID names the arrowhead marker for a stroke color.
+func arrowID(color string) string {
+	return "arrow-" + strings.Map(func(r rune) rune {
+		if 'a' <= r && r <= 'z' || '0' <= r && r <= '9' {
+			return r
+		}
+		return -1
+	}, strings.ToLower(color))
+}
+
+func writeScene(b *bytes.Buffer, sc scene) {
+	for _, s := range sc.Shapes {
+		writeShape(b, s)
+	}
+	for _, c := range sc.Connectors {
+		var pts []byte
+		for i, p := range c.Points {
+			if i > 0 {
+				pts = append(pts, ' ')
+			}
+			pts = fmt.Appendf(pts, "%s,%s", num(p.X), num(p.Y))
+		}
+		fmt.Fprintf(b, `<polyline points="%s" fill="none" stroke="%s" marker-end="url(#%s)"/>`+"\n", pts, paint(c.Stroke), arrowID(c.Stroke))
+		if c.Label != "" {
+			mid := c.Points[len(c.Points)/2]
+			if len(c.Points)%2 == 0 {
+				a := c.Points[len(c.Points)/2-1]
+				mid = ir.Point{X: (a.X + mid.X) / 2, Y: (a.Y + mid.Y) / 2}
+			}
+			fmt.Fprintf(b, `<text x="%s" y="%s" font-size="11" text-anchor="middle" fill="%s">%s</text>`+"\n",
+				num(mid.X), num(mid.Y-4), paint(c.Stroke), esc(c.Label))
+		}
+	}
+}
+
+func writeShape(b *bytes.Buffer, s shape) {
+	fill, stroke := paint(s.Fill), paint(s.Stroke)
+	x, y, w, h := s.Box.X, s.Box.Y, s.Box.Width, s.Box.Height
+
+	if w > 0 && h > 0 && (s.Fill != "" || s.Stroke != "") {
+		switch {
+		case s.Ellipse:
+			fmt.Fprintf(b, `<ellipse id="%s" cx="%s" cy="%s" rx="%s" ry="%s" fill="%s" stroke="%s" stroke-width="%s"/>`+"\n",
+				esc(s.ID), num(x+w/2), num(y+h/2), num(w/2), num(h/2), fill, stroke, num(s.StrokeWidth))
+		default:
+			fmt.Fprintf(b, `<rect id="%s" x="%s" y="%s" width="%s" height="%s" rx="%s" fill="%s" stroke="%s" stroke-width="%s"/>`+"\n",
+				esc(s.ID), num(x), num(y), num(w), num(h), num(s.Radius), fill, stroke, num(s.StrokeWidth))
+		}
+	}
+	if s.Placeholder && w > 0 && h > 0 {
+		fmt.Fprintf(b, `<path d="M%s,%s L%s,%s M%s,%s L%s,%s" stroke="#999999"/>`+"\n",
+			num(x), num(y), num(x+w), num(y+h), num(x+w), num(y), num(x), num(y+h))
+	}
+
+	if len(s.Lines) == 0 {
+		return
+	}
+	anchor := map[string]string{"left": "start", "center": "middle", "right": "end"}[s.Align]
+	tx, ty := s.textOrigin()
+	weight := "normal"
+	if s.Bold {
+		weight = "bold"
+	}
+	fmt.Fprintf(b, `<text x="%s" y="%s" font-size="%s" font-weight="%s" fill="%s" text-anchor="%s">`,
+		num(tx), num(ty), num(s.FontSize), weight, paint(s.FontColor), anchor)
+	for i, line := range s.Lines {
+		dy := "0"
+		if i > 0 {
+			dy = num(s.FontSize * lineHeight)
+		}
+		fmt.Fprintf(b, `<tspan x="%s" dy="%s">%s</tspan>`, num(tx), dy, esc(line))
+	}
+	b.WriteString("</text>\n")
+}
+
+// paint renders a resolved color as an attribute value.
+func paint(color string) string {
+	if color == "" {
+		return "none"
+	}
+	return esc(color)
+}
+
+// num renders a coordinate with at most two decimals.
+func num(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
+
+func esc(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
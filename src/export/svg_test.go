@@ -0,0 +1,64 @@
+package export
+
+import (
+	"encoding/xml"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"holoplan-cli/src/ir"
+)
+
+// flow is a Draw.io page with three boxes joined by a default and two red
+// connectors.
+const flow = `<mxGraphModel><root><mxCell id="0"/><mxCell id="1" parent="0"/>` +
+	`<mxCell id="a" value="Start" vertex="1" parent="1"><mxGeometry x="0" y="0" width="80" height="40" as="geometry"/></mxCell>` +
+	`<mxCell id="b" value="Middle" vertex="1" parent="1"><mxGeometry x="200" y="0" width="80" height="40" as="geometry"/></mxCell>` +
+	`<mxCell id="c" value="End" vertex="1" parent="1"><mxGeometry x="400" y="0" width="80" height="40" as="geometry"/></mxCell>` +
+	`<mxCell id="e1" value="next" edge="1" source="a" target="b" parent="1"><mxGeometry relative="1" as="geometry"/></mxCell>` +
+	`<mxCell id="e2" style="strokeColor=#FF0000;" edge="1" source="b" target="c" parent="1"><mxGeometry relative="1" as="geometry"/></mxCell>` +
+	`<mxCell id="e3" style="strokeColor=#FF0000;" edge="1" source="a" target="c" parent="1"><mxGeometry relative="1" as="geometry"/></mxCell>` +
+	`</root></mxGraphModel>`
+
+func TestSVGMarkers(t *testing.T) {
+	doc, err := ir.FromDrawio(flow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc.Pages[0].Name = "Flow & more"
+	tests := []struct {
+		name string
+		svg  []byte
+	}{
+		{name: "page", svg: SVG(doc.Pages[0])},
+		{name: "document", svg: SVGDocument(&ir.Document{Pages: []*ir.Page{doc.Pages[0], doc.Pages[0]}})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := string(tt.svg)
+			if err := xml.Unmarshal(tt.svg, new(struct{})); err != nil {
+				t.Fatalf("not well-formed XML: %v\n%s", err, out)
+			}
+			if strings.Contains(out, "context-stroke") {
+				t.Error("markers use context-stroke")
+			}
+
+			var markers []string
+			for _, m := range regexp.MustCompile(`<marker id="([^"]+)"`).FindAllStringSubmatch(out, -1) {
+				markers = append(markers, m[1])
+			}
+			if want := []string{"arrow-000000", "arrow-ff0000"}; !slices.Equal(markers, want) {
+				t.Fatalf("markers = %v, want one per stroke color %v", markers, want)
+			}
+			if !strings.Contains(out, `fill="#ff0000"/></marker>`) {
+				t.Error("the red connectors' arrowhead is not filled red")
+			}
+			for _, m := range regexp.MustCompile(`marker-end="url\(#([^)]+)\)"`).FindAllStringSubmatch(out, -1) {
+				if !slices.Contains(markers, m[1]) {
+					t.Errorf("connector refers to undefined marker %q", m[1])
+				}
+			}
+		})
+	}
+}
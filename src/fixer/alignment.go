@@ -0,0 +1,117 @@
+// src/fixer/alignment.go
+package fixer
+
+import (
+	"fmt"
+	"sort"
+
+	"holoplan-cli/src/validator"
+)
+
+// applySuggestions moves each flagged cell to the geometry its diagnostic
+// suggests. Suggestions are absolute, so the offset from the cell's absolute
+// bounds is applied to its relative geometry.
+func applySuggestions(l *layout, diags []validator.Diagnostic, verb string) []string {
+	var applied []string
+	for _, d := range diags {
+		if d.Suggestion == nil || len(d.Bounds) == 0 {
+			continue
+		}
+		id := d.CellIDs[0]
+		if l.stale(id) {
+			continue
+		}
+		b, ok := l.get(id)
+		if !ok {
+			continue
+		}
+
+		s, abs := *d.Suggestion, d.Bounds[0]
+		b.X += s.X - abs.X
+		b.Y += s.Y - abs.Y
+		b.Width, b.Height = s.Width, s.Height
+		l.set(id, b)
+		applied = append(applied, fmt.Sprintf("%s %s to (%g, %g) %gx%g", verb, l.name(id), s.X, s.Y, s.Width, s.Height))
+	}
+	return applied
+}
+
+func fixAlignment(l *layout, diags []validator.Diagnostic, rules validator.Rules) []string {
+	if !rules.Alignment.AutoFix {
+		return nil
+	}
+	return applySuggestions(l, diags, "aligned")
+}
+
+func fixGrid(l *layout, diags []validator.Diagnostic, rules validator.Rules) []string {
+	if !rules.Grid.AutoFix {
+		return nil
+	}
+	return applySuggestions(l, diags, "snapped")
+}
+
+func fixFullWidth(l *layout, diags []validator.Diagnostic, rules validator.Rules) []string {
+	if !rules.FullWidth.AutoFix {
+		return nil
+	}
+	return applySuggestions(l, diags, "stretched")
+}
+
+func fixTextFit(l *layout, diags []validator.Diagnostic, rules validator.Rules) []string {
+	if !rules.TextFit.AutoFix {
+		return nil
+	}
+	return applySuggestions(l, diags, "resized")
+}
+
+func fixMinSize(l *layout, diags []validator.Diagnostic, rules validator.Rules) []string {
+	if !rules.MinSize.AutoFix {
+		return nil
+	}
+	return applySuggestions(l, diags, "enlarged")
+}
+
+// fixSpacing evens out gaps by moving the lower cell of each uneven pair,
+// together with every sibling at or below it so the gaps further down are
+// kept. Pairs are handled bottom-up so earlier moves never invalidate later
+// diagnostics.
+func fixSpacing(l *layout, diags []validator.Diagnostic, rules validator.Rules) []string {
+	if !rules.Spacing.AutoFix {
+		return nil
+	}
+
+	diags = append([]validator.Diagnostic(nil), diags...)
+	sort.SliceStable(diags, func(i, j int) bool {
+		return diags[i].Bounds[0].Y > diags[j].Bounds[0].Y
+	})
+
+	var applied []string
+	for _, d := range diags {
+		if d.Suggestion == nil || len(d.Bounds) == 0 {
+			continue
+		}
+		id := d.CellIDs[0]
+		// Cells already moved in this pass are left for the next one
+		if l.stale(id) {
+			continue
+		}
+		b, ok := l.get(id)
+		if !ok {
+			continue
+		}
+
+		dy := d.Suggestion.Y - d.Bounds[0].Y
+		parent, top := l.parent(id), b.Y
+		for _, other := range l.order {
+			if !l.isVertex(other) || l.parent(other) != parent {
+				continue
+			}
+			if ob, ok := l.get(other); ok && ob.Y >= top {
+				ob.Y += dy
+				l.set(other, ob)
+			}
+		}
+		applied = append(applied, fmt.Sprintf("moved %s and everything below it by %gpx to even out spacing", l.name(id), dy))
+	}
+	return applied
+}
@@ -0,0 +1,193 @@
+// src/fixer/fixer.go
+package fixer
+
+import (
+	"fmt"
+	"strings"
+
+	"holoplan-cli/src/shared"
+	"holoplan-cli/src/validator"
+
+	"github.com/beevik/etree"
+)
+
+// Margin is the spacing, in px, used when fixes move elements apart.
+const Margin = 10
+
+// maxPasses bounds the fix → re-validate loop; a fix can expose another
+// violation (e.g. moving a footer down creates a collision) but never loops.
+const maxPasses = 5
+
+// Result describes what Fix changed and what is still wrong.
+type Result struct {
+	XML       string
+	Applied   []string
+	Remaining []validator.Diagnostic
+}
+
+// Fix applies rule-specific deterministic fixes to a single <mxGraphModel>,
+// re-running the validator after each pass until nothing more can be fixed.
+// Rules without a mechanical fix (vertical flow, edges) are left for the
+// LLM resolver or a human.
+func Fix(xml string, rules validator.Rules) (Result, error) {
+	res := Result{XML: xml}
+
+	for pass := 0; pass < maxPasses; pass++ {
+		diags, err := validator.ValidateWith(res.XML, rules)
+		if err != nil {
+			return res, err
+		}
+		res.Remaining = diags
+		if len(diags) == 0 {
+			return res, nil
+		}
+
+		fixed, applied, err := applyFixes(res.XML, diags, rules)
+		if err != nil {
+			return res, err
+		}
+		if len(applied) == 0 {
+			return res, nil
+		}
+		res.XML = fixed
+		res.Applied = append(res.Applied, applied...)
+	}
+
+	diags, err := validator.ValidateWith(res.XML, rules)
+	if err != nil {
+		return res, err
+	}
+	res.Remaining = diags
+	return res, nil
+}
+
+// applyFixes runs one pass of fixes for the given diagnostics. Structural
+// repair runs first so the rest can rely on a sound tree, and overlap
+// resolution runs last since the other fixes move elements around.
+func applyFixes(xml string, diags []validator.Diagnostic, rules validator.Rules) (string, []string, error) {
+	byRule := make(map[string][]validator.Diagnostic)
+	for _, d := range diags {
+		byRule[d.Rule] = append(byRule[d.Rule], d)
+	}
+
+	var applied []string
+
+	if len(byRule[validator.RuleStructure]) > 0 {
+		repaired, fixes, err := validator.Repair(xml)
+		if err != nil {
+			return xml, nil, err
+		}
+		xml = repaired
+		applied = append(applied, fixes...)
+	}
+
+	// Only sanitize XML that does not parse as-is; the sanitizer is lossy for
+	// hand-edited files (e.g. numeric character references in labels)
+	l, err := parseLayout(xml)
+	if err != nil {
+		sanitized, fixes, serr := shared.SanitizeXMLFixes(xml)
+		if serr != nil {
+			return xml, nil, serr
+		}
+		if l, err = parseLayout(sanitized); err != nil {
+			return xml, nil, err
+		}
+		applied = append(applied, fixes...)
+	}
+
+	for _, fix := range []struct {
+		rule string
+		fn   func(*layout, []validator.Diagnostic, validator.Rules) []string
+	}{
+		{validator.RuleSize, fixSizes},
+		{validator.RuleTextFit, fixTextFit},
+		{validator.RuleMinSize, fixMinSize},
+		{validator.RuleBounds, fixBounds},
+		{validator.RuleContainment, fixContainment},
+		{validator.RuleSemanticZone, fixZones},
+		{validator.RuleFullWidth, fixFullWidth},
+		{validator.RuleAlignment, fixAlignment},
+		{validator.RuleSpacing, fixSpacing},
+		{validator.RuleGrid, fixGrid},
+		{validator.RuleHidden, fixHidden},
+	} {
+		if len(byRule[fix.rule]) > 0 {
+			applied = append(applied, fix.fn(l, byRule[fix.rule], rules)...)
+		}
+	}
+
+	out, err := l.doc.WriteToString()
+	if err != nil {
+		return xml, nil, fmt.Errorf("failed to serialize fixed XML: %w", err)
+	}
+	out = strings.TrimPrefix(out, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+
+	if len(byRule[validator.RuleCollision]) > 0 {
+		resolved, moves, err := shared.ResolveOverlapsReport(out, Margin)
+		if err != nil {
+			return xml, nil, err
+		}
+		out = resolved
+		for _, m := range moves {
+			applied = append(applied, m.String())
+		}
+	}
+
+	return out, applied, nil
+}
+
+// PageResult is the outcome of fixing one page of a document.
+type PageResult struct {
+	Page string
+	Result
+}
+
+// FixDocument applies Fix to every page of a Draw.io document, returning the
+// fixed document and per-page results in page order.
+func FixDocument(raw string, rules validator.Rules) (string, []PageResult, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromString(raw); err != nil {
+		return "", nil, fmt.Errorf("failed to parse document: %w", err)
+	}
+
+	pages, err := validator.PageModels(doc)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var results []PageResult
+	for _, page := range pages {
+		pageDoc := etree.NewDocument()
+		pageDoc.SetRoot(page.Model.Copy())
+		pageXML, err := pageDoc.WriteToString()
+		if err != nil {
+			return "", nil, fmt.Errorf("page %q: %w", page.Name, err)
+		}
+
+		res, err := Fix(pageXML, rules)
+		if err != nil {
+			return "", nil, fmt.Errorf("page %q: %w", page.Name, err)
+		}
+		results = append(results, PageResult{Page: page.Name, Result: res})
+
+		fixedDoc := etree.NewDocument()
+		if err := fixedDoc.ReadFromString(res.XML); err != nil {
+			return "", nil, fmt.Errorf("page %q: fixed XML is malformed: %w", page.Name, err)
+		}
+
+		if parent := page.Model.Parent(); parent != nil && parent.Tag == "diagram" {
+			idx := page.Model.Index()
+			parent.RemoveChild(page.Model)
+			parent.InsertChildAt(idx, fixedDoc.Root())
+		} else {
+			doc.SetRoot(fixedDoc.Root())
+		}
+	}
+
+	doc.Indent(2)
+	out, err := doc.WriteToString()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to serialize fixed document: %w", err)
+	}
+	return out, results, nil
+}
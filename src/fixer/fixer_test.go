@@ -0,0 +1,137 @@
+package fixer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"holoplan-cli/src/validator"
+)
+
+// page builds an mxGraphModel of top-level vertices given as
+// "label:x,y,w,h"; each cell's ID is its label. attrs go on the model.
+func page(attrs string, cells ...string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<mxGraphModel%s><root><mxCell id="0"/><mxCell id="1" parent="0"/>`, attrs)
+	for _, c := range cells {
+		label, geo, _ := strings.Cut(c, ":")
+		var x, y, w, h float64
+		fmt.Sscanf(geo, "%g,%g,%g,%g", &x, &y, &w, &h)
+		fmt.Fprintf(&b, `<mxCell id="%s" value="%s" style="rounded=0;" vertex="1" parent="1"><mxGeometry x="%g" y="%g" width="%g" height="%g" as="geometry"/></mxCell>`,
+			label, label, x, y, w, h)
+	}
+	b.WriteString(`</root></mxGraphModel>`)
+	return b.String()
+}
+
+func withSpacingFix() validator.Rules {
+	rules := validator.DefaultRules()
+	rules.Spacing.AutoFix = true
+	return rules
+}
+
+func TestFix(t *testing.T) {
+	tests := []struct {
+		name  string
+		xml   string
+		rules validator.Rules
+		want  map[string]string // label → "x,y,w,h" after fixing
+		fixes []string          // substrings of the applied fixes, in order
+	}{
+		{
+			name:  "layout is shifted out of negative coordinates",
+			xml:   page("", "Logo:-20,-10,100,50", "Content:50,100,300,200"),
+			want:  map[string]string{"Logo": "0,0,100,50", "Content": "70,110,300,200"},
+			fixes: []string{"shifted layout by (20, 10)"},
+		},
+		{
+			name: "elements are pulled onto a declared page",
+			xml:  page(` pageWidth="500" pageHeight="1000"`, "Logo:450,0,100,50", "Gallery:0,100,800,100"),
+			want: map[string]string{"Logo": "400,0,100,50", "Gallery": "0,100,500,100"},
+			fixes: []string{
+				`moved "Logo" (Logo) left to x=400`,
+				`shrank "Gallery" (Gallery) to the page width 500`,
+			},
+		},
+		{
+			name: "an undeclared page is not assumed",
+			xml:  page("", "Logo:0,0,100,50", "Gallery:0,100,1200,100"),
+			want: map[string]string{"Logo": "0,0,100,50", "Gallery": "0,100,1200,100"},
+		},
+		{
+			name:  "navbar moves to the top",
+			xml:   page("", "Content:0,0,800,100", "Navbar:0,200,800,60"),
+			want:  map[string]string{"Navbar": "0,0,800,60", "Content": "0,70,800,100"},
+			fixes: []string{`moved "Navbar" (Navbar) to the top`},
+		},
+		{
+			name:  "footer moves to the bottom",
+			xml:   page("", "Footer:0,0,800,50", "Content:0,100,800,100"),
+			want:  map[string]string{"Content": "0,0,800,100", "Footer": "0,110,800,50"},
+			fixes: []string{`moved "Footer" (Footer) to the bottom`},
+		},
+		{
+			name:  "the gap a footer leaves is closed without overlap",
+			xml:   page("", "Intro:0,0,800,60", "Footer:0,60,800,50", "Content:0,110,800,100"),
+			want:  map[string]string{"Intro": "0,0,800,60", "Content": "0,60,800,100", "Footer": "0,170,800,50"},
+			fixes: []string{`moved "Footer" (Footer) to the bottom`},
+		},
+		{
+			name:  "elements below a footer stay clear of one beside it",
+			xml:   page("", "Footer:0,0,300,50", "Aside:400,0,300,80", "Content:0,100,800,100"),
+			want:  map[string]string{"Aside": "400,0,300,80", "Content": "0,90,800,100", "Footer": "0,200,300,50"},
+			fixes: []string{`moved "Footer" (Footer) to the bottom`},
+		},
+		{
+			name:  "footer moves to the foot of a declared page",
+			xml:   page(` pageWidth="800" pageHeight="1100"`, "Content:0,0,800,100", "Footer:0,300,800,50"),
+			want:  map[string]string{"Content": "0,0,800,100", "Footer": "0,1050,800,50"},
+			fixes: []string{`moved "Footer" (Footer) to the bottom`},
+		},
+		{
+			name:  "uneven gaps are evened out when auto-fix is on",
+			xml:   page("", "Name:10,0,200,40", "Email:10,60,200,40", "Phone:10,120,200,40", "Notes:10,200,200,40"),
+			rules: withSpacingFix(),
+			want:  map[string]string{"Phone": "10,120,200,40", "Notes": "10,180,200,40"},
+			fixes: []string{`moved "Notes" (Notes) and everything below it by -20px`},
+		},
+		{
+			name: "uneven gaps are only reported by default",
+			xml:  page("", "Name:10,0,200,40", "Email:10,60,200,40", "Phone:10,120,200,40", "Notes:10,200,200,40"),
+			want: map[string]string{"Notes": "10,200,200,40"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := tt.rules
+			if tt.rules.Structure.Severity == "" {
+				rules = validator.DefaultRules()
+			}
+			res, err := Fix(tt.xml, rules)
+			if err != nil {
+				t.Fatalf("Fix: %v", err)
+			}
+
+			l, err := parseLayout(res.XML)
+			if err != nil {
+				t.Fatalf("fixed XML does not parse: %v", err)
+			}
+			for id, want := range tt.want {
+				b, _ := l.get(id)
+				if got := fmt.Sprintf("%g,%g,%g,%g", b.X, b.Y, b.Width, b.Height); got != want {
+					t.Errorf("%s = %s, want %s", id, got, want)
+				}
+			}
+
+			if len(res.Applied) != len(tt.fixes) {
+				t.Fatalf("applied %q, want %d fixes", res.Applied, len(tt.fixes))
+			}
+			for i, want := range tt.fixes {
+				if !strings.Contains(res.Applied[i], want) {
+					t.Errorf("fix %d = %q, want it to mention %q", i, res.Applied[i], want)
+				}
+			}
+		})
+	}
+}
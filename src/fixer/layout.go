@@ -0,0 +1,216 @@
+// src/fixer/layout.go
+package fixer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"holoplan-cli/src/shared"
+
+	"github.com/beevik/etree"
+)
+
+// layout is an editable view of an <mxGraphModel>: cells by ID, in document
+// order, with access to their geometry. <object>/<UserObject> wrappers are
+// treated as the cell they wrap.
+type layout struct {
+	doc   *etree.Document
+	root  *etree.Element
+	cells map[string]*etree.Element
+	order []string
+
+	pageWidth, pageHeight float64
+
+	// moved records cells whose geometry changed in this pass, so fixes
+	// computed from the pass's diagnostics don't stack on top of each other
+	moved map[string]bool
+}
+
+func parseLayout(xml string) (*layout, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromString(xml); err != nil {
+		return nil, fmt.Errorf("failed to parse XML: %w", err)
+	}
+
+	model := doc.FindElement("//mxGraphModel")
+	if model == nil {
+		return nil, fmt.Errorf("no <mxGraphModel> found")
+	}
+	root := model.SelectElement("root")
+	if root == nil {
+		return nil, fmt.Errorf("<mxGraphModel> has no <root>")
+	}
+
+	l := &layout{
+		doc:        doc,
+		root:       root,
+		cells:      make(map[string]*etree.Element),
+		moved:      make(map[string]bool),
+		pageWidth:  parseNum(model.SelectAttrValue("pageWidth", "0")),
+		pageHeight: parseNum(model.SelectAttrValue("pageHeight", "0")),
+	}
+	for _, el := range root.ChildElements() {
+		if el.Tag != "mxCell" && el.Tag != "object" && el.Tag != "UserObject" {
+			continue
+		}
+		id := el.SelectAttrValue("id", "")
+		if _, dup := l.cells[id]; dup || id == "" {
+			continue
+		}
+		l.cells[id] = el
+		l.order = append(l.order, id)
+	}
+	return l, nil
+}
+
+// cell returns the mxCell carrying parent/vertex/geometry for an ID.
+func (l *layout) cell(id string) *etree.Element {
+	el, ok := l.cells[id]
+	if !ok {
+		return nil
+	}
+	if el.Tag == "mxCell" {
+		return el
+	}
+	return el.SelectElement("mxCell")
+}
+
+func (l *layout) label(id string) string {
+	el := l.cells[id]
+	if el == nil {
+		return ""
+	}
+	if el.Tag == "mxCell" {
+		return el.SelectAttrValue("value", "")
+	}
+	return el.SelectAttrValue("label", "")
+}
+
+func (l *layout) style(id string) string {
+	if c := l.cell(id); c != nil {
+		return c.SelectAttrValue("style", "")
+	}
+	return ""
+}
+
+func (l *layout) parent(id string) string {
+	if c := l.cell(id); c != nil {
+		return c.SelectAttrValue("parent", "")
+	}
+	return ""
+}
+
+func (l *layout) isVertex(id string) bool {
+	c := l.cell(id)
+	return c != nil && c.SelectAttrValue("vertex", "") == "1"
+}
+
+// topLevel reports whether a vertex sits directly on a layer, so its
+// geometry is in page coordinates.
+func (l *layout) topLevel(id string) bool {
+	return l.isVertex(id) && !l.isVertex(l.parent(id))
+}
+
+// topLevelVertices returns the IDs of every vertex placed directly on a layer.
+func (l *layout) topLevelVertices() []string {
+	var ids []string
+	for _, id := range l.order {
+		if l.topLevel(id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// get returns a cell's geometry in its parent's coordinate space.
+func (l *layout) get(id string) (shared.Bounds, bool) {
+	c := l.cell(id)
+	if c == nil {
+		return shared.Bounds{}, false
+	}
+	g := c.SelectElement("mxGeometry")
+	if g == nil {
+		return shared.Bounds{}, false
+	}
+	return shared.Bounds{
+		X:      parseNum(g.SelectAttrValue("x", "0")),
+		Y:      parseNum(g.SelectAttrValue("y", "0")),
+		Width:  parseNum(g.SelectAttrValue("width", "0")),
+		Height: parseNum(g.SelectAttrValue("height", "0")),
+	}, true
+}
+
+// set writes a cell's geometry, creating the <mxGeometry> if needed.
+func (l *layout) set(id string, b shared.Bounds) {
+	c := l.cell(id)
+	if c == nil {
+		return
+	}
+	if old, ok := l.get(id); ok && old == b {
+		return
+	}
+	g := c.SelectElement("mxGeometry")
+	if g == nil {
+		g = c.CreateElement("mxGeometry")
+		g.CreateAttr("as", "geometry")
+	}
+	g.CreateAttr("x", formatNum(b.X))
+	g.CreateAttr("y", formatNum(b.Y))
+	g.CreateAttr("width", formatNum(b.Width))
+	g.CreateAttr("height", formatNum(b.Height))
+	l.moved[id] = true
+}
+
+// stale reports whether a cell or one of its containers already moved in
+// this pass, which makes its diagnostic's absolute coordinates out of date.
+func (l *layout) stale(id string) bool {
+	seen := make(map[string]bool)
+	for ; id != "" && !seen[id]; id = l.parent(id) {
+		if l.moved[id] {
+			return true
+		}
+		seen[id] = true
+	}
+	return false
+}
+
+// extent returns the bounding box of the given top-level cells, skipping one.
+func (l *layout) extent(ids []string, skip string) (minX, minY, maxX, maxY float64, ok bool) {
+	for _, id := range ids {
+		if id == skip {
+			continue
+		}
+		b, found := l.get(id)
+		if !found {
+			continue
+		}
+		if !ok {
+			minX, minY, maxX, maxY = b.X, b.Y, b.X+b.Width, b.Y+b.Height
+			ok = true
+			continue
+		}
+		minX = min(minX, b.X)
+		minY = min(minY, b.Y)
+		maxX = max(maxX, b.X+b.Width)
+		maxY = max(maxY, b.Y+b.Height)
+	}
+	return
+}
+
+// name renders a cell as `"Label" (id)` for fix descriptions.
+func (l *layout) name(id string) string {
+	if label := l.label(id); label != "" {
+		return fmt.Sprintf("%q (%s)", label, id)
+	}
+	return fmt.Sprintf("(%s)", id)
+}
+
+func parseNum(s string) float64 {
+	f, _ := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return f
+}
+
+func formatNum(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
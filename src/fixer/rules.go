@@ -0,0 +1,294 @@
+// src/fixer/rules.go
+package fixer
+
+import (
+	"fmt"
+	"math"
+
+	"holoplan-cli/src/taxonomy"
+	"holoplan-cli/src/validator"
+)
+
+// Default size given to elements with a zero or negative dimension, matching
+// the sanitizer's defaults for missing geometry.
+const (
+	defaultWidth  = 100
+	defaultHeight = 50
+)
+
+// fixSizes gives collapsed elements a usable default size.
+func fixSizes(l *layout, diags []validator.Diagnostic, _ validator.Rules) []string {
+	var applied []string
+	for _, d := range diags {
+		id := d.CellIDs[0]
+		b, ok := l.get(id)
+		if !ok {
+			continue
+		}
+		from := b
+		if b.Width <= 0 {
+			b.Width = defaultWidth
+		}
+		if b.Height <= 0 {
+			b.Height = defaultHeight
+		}
+		l.set(id, b)
+		applied = append(applied, fmt.Sprintf("resized %s from %gx%g to %gx%g",
+			l.name(id), from.Width, from.Height, b.Width, b.Height))
+	}
+	return applied
+}
+
+// fixBounds translates the whole layout out of negative space, then pulls
+// elements that stick out past the page edges back in (shrinking them if they
+// are larger than the page). Page edges come only from the model's own
+// pageWidth/pageHeight, never from the rule's fallback size, so an undeclared
+// page is not squeezed into an assumed one; the bottom edge is fixed only
+// when the rule enforces the page height. Only top-level elements are moved.
+func fixBounds(l *layout, diags []validator.Diagnostic, rules validator.Rules) []string {
+	var applied []string
+	top := l.topLevelVertices()
+
+	minX, minY, _, _, ok := l.extent(top, "")
+	if ok && (minX < 0 || minY < 0) {
+		dx, dy := max(0, -minX), max(0, -minY)
+		for _, id := range top {
+			b, _ := l.get(id)
+			b.X += dx
+			b.Y += dy
+			l.set(id, b)
+		}
+		applied = append(applied, fmt.Sprintf("shifted layout by (%g, %g) out of negative coordinates", dx, dy))
+	}
+
+	pageWidth, pageHeight := l.pageWidth, l.pageHeight
+	if !rules.Bounds.EnforceHeight {
+		pageHeight = 0
+	}
+	if pageWidth <= 0 && pageHeight <= 0 {
+		return applied
+	}
+
+	for _, d := range diags {
+		id := d.CellIDs[0]
+		if !l.topLevel(id) {
+			continue
+		}
+		b, _ := l.get(id)
+		from := b
+		if pageWidth > 0 && b.X+b.Width > pageWidth {
+			if b.Width > pageWidth {
+				b.X, b.Width = 0, pageWidth
+				applied = append(applied, fmt.Sprintf("shrank %s to the page width %g", l.name(id), pageWidth))
+			} else {
+				b.X = pageWidth - b.Width
+				applied = append(applied, fmt.Sprintf("moved %s left to x=%g to fit the page", l.name(id), b.X))
+			}
+		}
+		if pageHeight > 0 && b.Y+b.Height > pageHeight {
+			if b.Height > pageHeight {
+				b.Y, b.Height = 0, pageHeight
+				applied = append(applied, fmt.Sprintf("shrank %s to the page height %g", l.name(id), pageHeight))
+			} else {
+				b.Y = pageHeight - b.Height
+				applied = append(applied, fmt.Sprintf("moved %s up to y=%g to fit the page", l.name(id), b.Y))
+			}
+		}
+		if b != from {
+			l.set(id, b)
+		}
+	}
+	return applied
+}
+
+// fixContainment grows containers to enclose children that spill out.
+func fixContainment(l *layout, diags []validator.Diagnostic, _ validator.Rules) []string {
+	var applied []string
+	for _, d := range diags {
+		if len(d.CellIDs) < 2 {
+			continue
+		}
+		childID, parentID := d.CellIDs[0], d.CellIDs[1]
+		child, ok := l.get(childID)
+		if !ok {
+			continue
+		}
+		parent, ok := l.get(parentID)
+		if !ok {
+			continue
+		}
+
+		if child.X < 0 || child.Y < 0 {
+			child.X, child.Y = max(0, child.X), max(0, child.Y)
+			l.set(childID, child)
+			applied = append(applied, fmt.Sprintf("moved %s inside its container", l.name(childID)))
+		}
+
+		from := parent
+		parent.Width = max(parent.Width, child.X+child.Width+Margin)
+		parent.Height = max(parent.Height, child.Y+child.Height+Margin)
+		if parent != from {
+			l.set(parentID, parent)
+			applied = append(applied, fmt.Sprintf("grew %s from %gx%g to %gx%g to contain %s",
+				l.name(parentID), from.Width, from.Height, parent.Width, parent.Height, l.name(childID)))
+		}
+	}
+	return applied
+}
+
+// fixZones moves landmark components to their conventional region.
+func fixZones(l *layout, diags []validator.Diagnostic, rules validator.Rules) []string {
+	var applied []string
+	top := l.topLevelVertices()
+
+	for _, d := range diags {
+		id := d.CellIDs[0]
+		if !l.topLevel(id) {
+			continue
+		}
+		b, _ := l.get(id)
+		from := b
+		minX, minY, maxX, maxY, ok := l.extent(top, id)
+		if !ok {
+			continue
+		}
+
+		var what string
+		switch taxonomy.Classify(l.label(id), l.style(id)) {
+		case taxonomy.Navbar, taxonomy.Header:
+			l.moveToSlot(top, id, min(minY, b.Y))
+			what = "moved %s to the top"
+
+		case taxonomy.Breadcrumb:
+			slot := min(minY, b.Y)
+			for _, other := range top {
+				kind := taxonomy.Classify(l.label(other), l.style(other))
+				if other != id && (kind == taxonomy.Navbar || kind == taxonomy.Header) {
+					ob, _ := l.get(other)
+					slot = max(slot, ob.Y+ob.Height+Margin)
+				}
+			}
+			l.moveToSlot(top, id, slot)
+			what = "moved %s below the navbar"
+
+		case taxonomy.Footer:
+			l.moveToBottom(top, id)
+			// Short content on a declared page leaves the footer at the page's foot
+			if fb, _ := l.get(id); fb.Y+fb.Height < rules.SemanticZone.FooterMin*l.pageHeight {
+				fb.Y = l.pageHeight - fb.Height
+				l.set(id, fb)
+			}
+			what = "moved %s to the bottom"
+
+		case taxonomy.Modal:
+			// Centered on the page the validator measures the band against
+			z := rules.SemanticZone
+			b.Y = max(0, (z.ModalMin+z.ModalMax)/2*max(l.pageHeight, maxY)-b.Height/2)
+			l.set(id, b)
+			what = "centered %s vertically"
+
+		case taxonomy.Sidebar:
+			if b.X+b.Width/2 < (minX+maxX)/2 {
+				b.X = minX
+			} else {
+				b.X = maxX - b.Width
+			}
+			l.set(id, b)
+			what = "snapped %s to the nearest edge"
+
+		case taxonomy.FAB:
+			b.X, b.Y = maxX-b.Width, maxY-b.Height
+			l.set(id, b)
+			what = "moved %s to the bottom-right corner"
+
+		default:
+			continue
+		}
+
+		// A landmark already in the best place it can get is left for the report
+		if after, _ := l.get(id); after != from {
+			applied = append(applied, fmt.Sprintf(what+" at (%g, %g)", l.name(id), after.X, after.Y))
+		}
+	}
+	return applied
+}
+
+// moveToSlot lifts a top-level element to slotY and shifts the elements that
+// were between the slot and its old position down to make room.
+func (l *layout) moveToSlot(top []string, id string, slotY float64) {
+	b, _ := l.get(id)
+	oldY := b.Y
+	shift := b.Height + Margin
+
+	for _, other := range top {
+		if other == id {
+			continue
+		}
+		ob, _ := l.get(other)
+		if ob.Y >= slotY && ob.Y < oldY {
+			ob.Y += shift
+			l.set(other, ob)
+		}
+	}
+	b.Y = slotY
+	l.set(id, b)
+}
+
+// moveToBottom drops a top-level element below everything else, closing the
+// gap it leaves behind: the elements below it move up so the first lands
+// where it started, or a margin below anything beside it that stays put.
+func (l *layout) moveToBottom(top []string, id string) {
+	b, _ := l.get(id)
+	oldBottom := b.Y + b.Height
+
+	var below []string
+	nextTop, target := math.Inf(1), b.Y
+	for _, other := range top {
+		if other == id {
+			continue
+		}
+		ob, _ := l.get(other)
+		switch {
+		case ob.Y >= oldBottom:
+			below = append(below, other)
+			nextTop = min(nextTop, ob.Y)
+		case ob.Y+ob.Height > b.Y:
+			target = max(target, ob.Y+ob.Height+Margin)
+		}
+	}
+	if shift := nextTop - target; shift > 0 {
+		for _, other := range below {
+			ob, _ := l.get(other)
+			ob.Y -= shift
+			l.set(other, ob)
+		}
+	}
+
+	_, _, _, maxY, ok := l.extent(top, id)
+	if ok {
+		b.Y = maxY + Margin
+		l.set(id, b)
+	}
+}
+
+// fixHidden brings covered elements in front of the element hiding them.
+func fixHidden(l *layout, diags []validator.Diagnostic, _ validator.Rules) []string {
+	var applied []string
+	for _, d := range diags {
+		if len(d.CellIDs) < 2 {
+			continue
+		}
+		under, over := l.cells[d.CellIDs[0]], l.cells[d.CellIDs[1]]
+		if under == nil || over == nil || under.Parent() != l.root || over.Parent() != l.root {
+			continue
+		}
+		if under.Index() > over.Index() {
+			continue
+		}
+		l.root.RemoveChild(under)
+		l.root.InsertChildAt(over.Index()+1, under)
+		applied = append(applied, fmt.Sprintf("brought %s in front of %s",
+			l.name(d.CellIDs[0]), l.name(d.CellIDs[1])))
+	}
+	return applied
+}
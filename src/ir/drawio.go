@@ -0,0 +1,405 @@
+// src/ir/drawio.go
+package ir
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"holoplan-cli/src/shared"
+	"holoplan-cli/src/taxonomy"
+
+	"github.com/beevik/etree"
+)
+
+// FromDrawio converts a bare <mxGraphModel> or every <diagram> of an <mxfile>,
+// inflating compressed diagrams. Cells are read tolerantly: a cell whose
+// parent is missing becomes a top-level widget of the first frame, and cells
+// caught in a parent cycle are dropped, so structural problems are left for
+// the validator's structure rule to report.
+func FromDrawio(raw string) (*Document, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromString(raw); err != nil {
+		return nil, fmt.Errorf("failed to parse Draw.io XML: %w", err)
+	}
+	root := doc.Root()
+	if root == nil {
+		return nil, fmt.Errorf("document has no root element")
+	}
+
+	switch root.Tag {
+	case "mxGraphModel":
+		return &Document{Pages: []*Page{drawioPage("Page-1", "", root)}}, nil
+
+	case "mxfile":
+		out := &Document{}
+		for i, diagram := range root.SelectElements("diagram") {
+			name := diagram.SelectAttrValue("name", fmt.Sprintf("Page-%d", i+1))
+			model := diagram.SelectElement("mxGraphModel")
+			if model == nil {
+				inflated, err := shared.InflateDiagram(diagram.Text())
+				if err != nil {
+					return nil, fmt.Errorf("page %q: %w", name, err)
+				}
+				sub := etree.NewDocument()
+				if err := sub.ReadFromString(inflated); err != nil {
+					return nil, fmt.Errorf("page %q: failed to parse inflated diagram: %w", name, err)
+				}
+				if model = sub.Root(); model == nil || model.Tag != "mxGraphModel" {
+					return nil, fmt.Errorf("page %q: no <mxGraphModel> found", name)
+				}
+			}
+			out.Pages = append(out.Pages, drawioPage(name, diagram.SelectAttrValue("id", ""), model))
+		}
+		if len(out.Pages) == 0 {
+			return nil, fmt.Errorf("<mxfile> contains no <diagram> pages")
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("unsupported root element <%s>", root.Tag)
+}
+
+// drawioCell is one <mxCell>, unwrapped from its <object>/<UserObject>.
+type drawioCell struct {
+	el                  *etree.Element
+	id, parent, value   string
+	style               string
+	vertex, edge        bool
+	source, target      string
+	meta                map[string]string
+	widget              *Widget
+	frame               *Frame
+	x, y, width, height float64
+}
+
+func drawioPage(name, id string, model *etree.Element) *Page {
+	page := &Page{
+		ID:     id,
+		Name:   name,
+		Width:  attrNum(model, "pageWidth"),
+		Height: attrNum(model, "pageHeight"),
+	}
+
+	var cells []*drawioCell
+	byID := make(map[string]*drawioCell)
+	if root := model.SelectElement("root"); root != nil {
+		for _, el := range root.ChildElements() {
+			c := readCell(el)
+			if c == nil {
+				continue
+			}
+			cells = append(cells, c)
+			if _, dup := byID[c.id]; !dup {
+				byID[c.id] = c
+			}
+		}
+	}
+
+	// Layers (children of the root cell that are neither vertex nor edge) are
+	// frames; a layer repeating an earlier cell's ID collapses into it, since
+	// children resolve to the first cell with their parent's ID
+	for _, c := range cells {
+		parent, ok := byID[c.parent]
+		if c.vertex || c.edge || !ok || parent.parent != "" || c.parent == c.id || byID[c.id] != c {
+			continue
+		}
+		c.frame = &Frame{
+			ID:     c.id,
+			Name:   c.value,
+			Bounds: Rect{Width: page.Width, Height: page.Height},
+			Meta:   c.meta,
+		}
+		page.Frames = append(page.Frames, c.frame)
+	}
+
+	// Widgets first, so edges can find their parent widget's frame
+	for _, c := range cells {
+		if c.vertex {
+			c.widget = &Widget{
+				ID:       c.id,
+				Kind:     taxonomy.Classify(c.value, c.style),
+				Label:    c.value,
+				Geometry: Rect{X: c.x, Y: c.y, Width: c.width, Height: c.height},
+				Style:    drawioStyle(c.style),
+				Meta:     c.meta,
+			}
+		}
+	}
+
+	// frameOf follows parents up to a frame; ok is false for cycles
+	frameOf := func(c *drawioCell) (*Frame, bool) {
+		seen := map[*drawioCell]bool{}
+		for cur := c; ; {
+			if seen[cur] {
+				return nil, false
+			}
+			seen[cur] = true
+			p, ok := byID[cur.parent]
+			if !ok || p == cur || (p.widget == nil && p.frame == nil) {
+				return nil, true // orphan: the first frame adopts it
+			}
+			if p.frame != nil {
+				return p.frame, true
+			}
+			cur = p
+		}
+	}
+	firstFrame := func() *Frame {
+		if len(page.Frames) == 0 {
+			page.Frames = append(page.Frames, &Frame{ID: "1", Bounds: Rect{Width: page.Width, Height: page.Height}})
+		}
+		return page.Frames[0]
+	}
+
+	for _, c := range cells {
+		if !c.vertex && !c.edge {
+			continue
+		}
+		frame, ok := frameOf(c)
+		if !ok {
+			continue
+		}
+		if frame == nil {
+			frame = firstFrame()
+		}
+		parent := byID[c.parent]
+
+		if c.vertex {
+			if parent != nil && parent.widget != nil && parent != c {
+				parent.widget.Children = append(parent.widget.Children, c.widget)
+			} else {
+				frame.Widgets = append(frame.Widgets, c.widget)
+			}
+			continue
+		}
+
+		e := &Edge{
+			ID:     c.id,
+			Label:  c.value,
+			Source: c.source,
+			Target: c.target,
+			Style:  drawioStyle(c.style),
+			Meta:   c.meta,
+		}
+		if parent != nil && parent.widget != nil {
+			e.Parent = parent.id
+		}
+		if geo := c.el.SelectElement("mxGeometry"); geo != nil {
+			for _, pt := range geo.SelectElements("mxPoint") {
+				p := &Point{X: attrNum(pt, "x"), Y: attrNum(pt, "y")}
+				switch pt.SelectAttrValue("as", "") {
+				case "sourcePoint":
+					e.SourcePoint = p
+				case "targetPoint":
+					e.TargetPoint = p
+				}
+			}
+			if arr := geo.SelectElement("Array"); arr != nil {
+				for _, pt := range arr.SelectElements("mxPoint") {
+					e.Waypoints = append(e.Waypoints, Point{X: attrNum(pt, "x"), Y: attrNum(pt, "y")})
+				}
+			}
+		}
+		frame.Edges = append(frame.Edges, e)
+	}
+
+	return page
+}
+
+// readCell reads an <mxCell>, or the cell inside an <object>/<UserObject>
+// wrapper, whose id and label it takes; the wrapper's other attributes become
+// "drawio.<name>" metadata.
+func readCell(el *etree.Element) *drawioCell {
+	cell := el
+	var meta map[string]string
+	if el.Tag == "object" || el.Tag == "UserObject" {
+		cell = el.SelectElement("mxCell")
+		if cell == nil {
+			return nil
+		}
+		for _, a := range el.Attr {
+			if a.Key != "id" && a.Key != "label" {
+				if meta == nil {
+					meta = make(map[string]string)
+				}
+				meta["drawio."+a.Key] = a.Value
+			}
+		}
+	} else if el.Tag != "mxCell" {
+		return nil
+	}
+
+	c := &drawioCell{
+		el:     cell,
+		id:     el.SelectAttrValue("id", ""),
+		parent: cell.SelectAttrValue("parent", ""),
+		value:  cell.SelectAttrValue("value", ""),
+		style:  cell.SelectAttrValue("style", ""),
+		vertex: cell.SelectAttrValue("vertex", "") == "1",
+		edge:   cell.SelectAttrValue("edge", "") == "1",
+		source: cell.SelectAttrValue("source", ""),
+		target: cell.SelectAttrValue("target", ""),
+		meta:   meta,
+	}
+	if el != cell {
+		c.value = el.SelectAttrValue("label", "")
+	}
+	if geo := cell.SelectElement("mxGeometry"); geo != nil {
+		c.x, c.y = attrNum(geo, "x"), attrNum(geo, "y")
+		c.width, c.height = attrNum(geo, "width"), attrNum(geo, "height")
+	}
+	return c
+}
+
+func attrNum(el *etree.Element, key string) float64 {
+	f, _ := strconv.ParseFloat(strings.TrimSpace(el.SelectAttrValue(key, "")), 64)
+	return f
+}
+
+// ToDrawio renders the document as an <mxfile> with one <diagram> per page.
+func ToDrawio(d *Document) (string, error) {
+	doc := etree.NewDocument()
+	doc.CreateProcInst("xml", `version="1.0" encoding="UTF-8"`)
+	mxfile := doc.CreateElement("mxfile")
+	for i, p := range d.Pages {
+		diagram := mxfile.CreateElement("diagram")
+		if p.ID != "" {
+			diagram.CreateAttr("id", p.ID)
+		}
+		name := p.Name
+		if name == "" {
+			name = fmt.Sprintf("Page-%d", i+1)
+		}
+		diagram.CreateAttr("name", name)
+		diagram.AddChild(drawioModel(p))
+	}
+	doc.Indent(2)
+	return doc.WriteToString()
+}
+
+// DrawioModel renders one page as a bare <mxGraphModel>, the format of the
+// per-view files in output/.
+func DrawioModel(p *Page) (string, error) {
+	doc := etree.NewDocument()
+	doc.SetRoot(drawioModel(p))
+	doc.Indent(2)
+	return doc.WriteToString()
+}
+
+func drawioModel(p *Page) *etree.Element {
+	model := etree.NewElement("mxGraphModel")
+	if p.Width > 0 && p.Height > 0 {
+		model.CreateAttr("pageWidth", formatNum(p.Width))
+		model.CreateAttr("pageHeight", formatNum(p.Height))
+	}
+	root := model.CreateElement("root")
+	root.CreateElement("mxCell").CreateAttr("id", "0")
+
+	frames := p.Frames
+	if len(frames) == 0 {
+		frames = []*Frame{{ID: "1"}}
+	}
+	for _, f := range frames {
+		layer := root.CreateElement("mxCell")
+		layer.CreateAttr("id", f.ID)
+		if f.Name != "" {
+			layer.CreateAttr("value", f.Name)
+		}
+		layer.CreateAttr("parent", "0")
+
+		// Layers have no geometry, so frames are flattened onto the page
+		var visit func(ws []*Widget, parent string, ox, oy float64)
+		visit = func(ws []*Widget, parent string, ox, oy float64) {
+			for _, w := range ws {
+				label := w.Label
+				if label == "" {
+					label = w.Name
+				}
+				cell := drawioCellElement(root, w.ID, label, w.Meta)
+				cell.CreateAttr("style", w.DrawioStyle())
+				cell.CreateAttr("vertex", "1")
+				cell.CreateAttr("parent", parent)
+				geo := cell.CreateElement("mxGeometry")
+				geo.CreateAttr("x", formatNum(w.Geometry.X+ox))
+				geo.CreateAttr("y", formatNum(w.Geometry.Y+oy))
+				geo.CreateAttr("width", formatNum(w.Geometry.Width))
+				geo.CreateAttr("height", formatNum(w.Geometry.Height))
+				geo.CreateAttr("as", "geometry")
+				visit(w.Children, w.ID, 0, 0)
+			}
+		}
+		visit(f.Widgets, f.ID, f.Bounds.X, f.Bounds.Y)
+
+		for _, e := range f.Edges {
+			cell := drawioCellElement(root, e.ID, e.Label, e.Meta)
+			style := e.Style.Raw
+			if style == "" {
+				style = strings.Join(append([]string{"edgeStyle=orthogonalEdgeStyle"}, e.Style.drawio()...), ";")
+			}
+			cell.CreateAttr("style", style)
+			cell.CreateAttr("edge", "1")
+			parent := f.ID
+			if e.Parent != "" {
+				parent = e.Parent
+			}
+			cell.CreateAttr("parent", parent)
+			if e.Source != "" {
+				cell.CreateAttr("source", e.Source)
+			}
+			if e.Target != "" {
+				cell.CreateAttr("target", e.Target)
+			}
+			geo := cell.CreateElement("mxGeometry")
+			geo.CreateAttr("relative", "1")
+			geo.CreateAttr("as", "geometry")
+			for _, end := range []struct {
+				as string
+				pt *Point
+			}{{"sourcePoint", e.SourcePoint}, {"targetPoint", e.TargetPoint}} {
+				if end.pt != nil {
+					el := geo.CreateElement("mxPoint")
+					el.CreateAttr("x", formatNum(end.pt.X))
+					el.CreateAttr("y", formatNum(end.pt.Y))
+					el.CreateAttr("as", end.as)
+				}
+			}
+			if len(e.Waypoints) > 0 {
+				arr := geo.CreateElement("Array")
+				arr.CreateAttr("as", "points")
+				for _, pt := range e.Waypoints {
+					el := arr.CreateElement("mxPoint")
+					el.CreateAttr("x", formatNum(pt.X))
+					el.CreateAttr("y", formatNum(pt.Y))
+				}
+			}
+		}
+	}
+	return model
+}
+
+// drawioCellElement adds an <mxCell> to root, wrapped in an <object> carrying
+// the id, label and any "drawio.*" metadata when there is some.
+func drawioCellElement(root *etree.Element, id, label string, meta map[string]string) *etree.Element {
+	var attrs []string
+	for k := range meta {
+		if strings.HasPrefix(k, "drawio.") {
+			attrs = append(attrs, k)
+		}
+	}
+	if len(attrs) == 0 {
+		cell := root.CreateElement("mxCell")
+		cell.CreateAttr("id", id)
+		cell.CreateAttr("value", label)
+		return cell
+	}
+
+	sort.Strings(attrs)
+	obj := root.CreateElement("object")
+	obj.CreateAttr("id", id)
+	obj.CreateAttr("label", label)
+	for _, k := range attrs {
+		obj.CreateAttr(strings.TrimPrefix(k, "drawio."), meta[k])
+	}
+	return obj.CreateElement("mxCell")
+}
@@ -0,0 +1,108 @@
+package ir
+
+import (
+	"reflect"
+	"testing"
+)
+
+// builderModel is shaped like the builder's output: a layer, a container
+// with children in relative coordinates, labelled shapes, a wrapped cell
+// with metadata and connectors with pinned ends and waypoints.
+const builderModel = `<mxGraphModel pageWidth="850" pageHeight="1100">
+  <root>
+    <mxCell id="0"/>
+    <mxCell id="1" parent="0"/>
+    <mxCell id="2" value="Navbar" style="rounded=0;whiteSpace=wrap;html=1;fillColor=#dae8fc;strokeColor=#6c8ebf;" vertex="1" parent="1">
+      <mxGeometry x="0" y="0" width="850" height="60" as="geometry"/>
+    </mxCell>
+    <mxCell id="3" value="Login Form" style="rounded=1;whiteSpace=wrap;html=1;" vertex="1" parent="1">
+      <mxGeometry x="225" y="120" width="400" height="300" as="geometry"/>
+    </mxCell>
+    <mxCell id="4" value="Email" style="text;html=1;fontSize=14;fontStyle=1;" vertex="1" parent="3">
+      <mxGeometry x="20" y="20" width="360" height="40" as="geometry"/>
+    </mxCell>
+    <object id="5" label="Sign In" link="/dashboard">
+      <mxCell style="rounded=1;fillColor=#1ba1e2;fontColor=#ffffff;" vertex="1" parent="3">
+        <mxGeometry x="20" y="220" width="360" height="44" as="geometry"/>
+      </mxCell>
+    </object>
+    <mxCell id="6" value="Dashboard" style="rounded=0;" vertex="1" parent="1">
+      <mxGeometry x="225" y="500" width="400" height="200" as="geometry"/>
+    </mxCell>
+    <mxCell id="7" value="submit" style="edgeStyle=orthogonalEdgeStyle;endArrow=classic;" edge="1" parent="1" source="5" target="6">
+      <mxGeometry relative="1" as="geometry">
+        <Array as="points">
+          <mxPoint x="700" y="360"/>
+          <mxPoint x="700" y="600"/>
+        </Array>
+      </mxGeometry>
+    </mxCell>
+    <mxCell id="8" style="endArrow=classic;" edge="1" parent="1">
+      <mxGeometry relative="1" as="geometry">
+        <mxPoint x="10" y="800" as="sourcePoint"/>
+        <mxPoint x="200" y="800" as="targetPoint"/>
+      </mxGeometry>
+    </mxCell>
+  </root>
+</mxGraphModel>`
+
+func TestDrawioRoundTrip(t *testing.T) {
+	doc, err := FromDrawio(builderModel)
+	if err != nil {
+		t.Fatalf("FromDrawio: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		write func() (string, error)
+	}{
+		{"DrawioModel", func() (string, error) { return DrawioModel(doc.Pages[0]) }},
+		{"ToDrawio", func() (string, error) { return ToDrawio(doc) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := tt.write()
+			if err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			back, err := FromDrawio(out)
+			if err != nil {
+				t.Fatalf("FromDrawio of written XML: %v\n%s", err, out)
+			}
+			if len(back.Pages) != 1 {
+				t.Fatalf("got %d pages, want 1", len(back.Pages))
+			}
+			if !reflect.DeepEqual(back.Pages[0], doc.Pages[0]) {
+				t.Errorf("page changed in round trip:\ngot  %+v\nwant %+v\n%s", back.Pages[0], doc.Pages[0], out)
+			}
+
+			// Output must not depend on map iteration order
+			for range 20 {
+				again, err := tt.write()
+				if err != nil {
+					t.Fatalf("write: %v", err)
+				}
+				if again != out {
+					t.Fatalf("output differs between runs:\n%s\n---\n%s", out, again)
+				}
+			}
+		})
+	}
+}
+
+func TestDrawioPoints(t *testing.T) {
+	doc, err := FromDrawio(builderModel)
+	if err != nil {
+		t.Fatalf("FromDrawio: %v", err)
+	}
+	edges := doc.Pages[0].Frames[0].Edges
+	if len(edges) != 2 {
+		t.Fatalf("got %d edges, want 2", len(edges))
+	}
+	if got := edges[0].Waypoints; !reflect.DeepEqual(got, []Point{{700, 360}, {700, 600}}) {
+		t.Errorf("waypoints = %v", got)
+	}
+	if sp, tp := edges[1].SourcePoint, edges[1].TargetPoint; sp == nil || tp == nil || *sp != (Point{10, 800}) || *tp != (Point{200, 800}) {
+		t.Errorf("source/target points = %v, %v", sp, tp)
+	}
+}
@@ -0,0 +1,396 @@
+// src/ir/figma.go
+package ir
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"holoplan-cli/src/taxonomy"
+)
+
+// FromFigma converts Figma JSON: a single view (the builder's output, whose
+// "document" is the view's root FRAME) becomes one page, and a merged
+// DOCUMENT becomes one page per CANVAS. Node coordinates are relative to the
+// parent, as the builder prompt asks.
+//
+// Figma layers a label beside its background instead of nesting it, so a
+// TEXT node lying inside an earlier non-TEXT sibling (a label on a button, a
+// placeholder in an input) becomes that sibling's child. Nodes without an id,
+// without a usable bounding box or with a duplicate id are skipped.
+func FromFigma(raw string) (*Document, error) {
+	var file map[string]any
+	if err := json.Unmarshal([]byte(raw), &file); err != nil {
+		return nil, fmt.Errorf("invalid Figma JSON: %w", err)
+	}
+	root, ok := file["document"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("missing top-level \"document\" node")
+	}
+
+	name, _ := file["name"].(string)
+	doc := &Document{Name: name}
+	seen := make(map[string]bool)
+
+	if t, _ := root["type"].(string); t != "DOCUMENT" {
+		page := &Page{Name: str(root, "name")}
+		if f := figmaFrame(root, seen); f != nil {
+			page.Frames = append(page.Frames, f)
+			page.Width, page.Height = f.Bounds.X+f.Bounds.Width, f.Bounds.Y+f.Bounds.Height
+		}
+		doc.Pages = append(doc.Pages, page)
+		return doc, nil
+	}
+
+	canvases, _ := root["children"].([]any)
+	for i, c := range canvases {
+		canvas, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		page := &Page{ID: str(canvas, "id"), Name: str(canvas, "name")}
+		if page.Name == "" {
+			page.Name = fmt.Sprintf("Page %d", i+1)
+		}
+		frames, _ := canvas["children"].([]any)
+		for _, fr := range frames {
+			if node, ok := fr.(map[string]any); ok {
+				if f := figmaFrame(node, seen); f != nil {
+					page.Frames = append(page.Frames, f)
+				}
+			}
+		}
+		page.Width, page.Height = page.Extent()
+		doc.Pages = append(doc.Pages, page)
+	}
+	return doc, nil
+}
+
+// figmaFrame converts a view's root node and everything below it.
+func figmaFrame(node map[string]any, seen map[string]bool) *Frame {
+	id := str(node, "id")
+	box, ok := figmaBounds(node)
+	if id == "" || !ok || seen[id] {
+		return nil
+	}
+	seen[id] = true
+
+	f := &Frame{ID: id, Name: str(node, "name"), Bounds: box, Meta: figmaMeta(node)}
+	f.Style, _ = figmaStyle(node)
+	f.Widgets = figmaWidgets(node, seen)
+	return f
+}
+
+func figmaWidgets(node map[string]any, seen map[string]bool) []*Widget {
+	children, _ := node["children"].([]any)
+
+	var out []*Widget
+	for _, c := range children {
+		child, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		id := str(child, "id")
+		box, ok := figmaBounds(child)
+		if id == "" || !ok || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		w := &Widget{ID: id, Name: str(child, "name"), Geometry: box, Meta: figmaMeta(child)}
+		var text bool
+		w.Style, text = figmaStyle(child)
+		if text {
+			w.Label = str(child, "characters")
+			w.Kind = taxonomy.Text
+		} else {
+			w.Kind = taxonomy.Classify(w.Name, "")
+			w.Children = figmaWidgets(child, seen)
+		}
+
+		placed := false
+		if text {
+			for _, s := range out {
+				if s.Meta["figma.type"] != "TEXT" && contains(s.Geometry, box) {
+					w.Geometry.X -= s.Geometry.X
+					w.Geometry.Y -= s.Geometry.Y
+					s.Children = append(s.Children, w)
+					placed = true
+					break
+				}
+			}
+		}
+		if !placed {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+// figmaStyle maps a node's paints and text style. Figma nodes are transparent
+// unless they have a backgroundColor or fills; a TEXT node's fill is its font
+// color. text reports whether the node is a TEXT node.
+func figmaStyle(node map[string]any) (s Style, text bool) {
+	text = str(node, "type") == "TEXT"
+
+	fill := ""
+	if c, ok := hexColor(node["backgroundColor"]); ok {
+		fill = c
+	} else if c, ok := firstPaint(node["fills"]); ok {
+		fill = c
+	}
+	if text {
+		s.FontColor, s.Fill, s.Stroke = fill, "none", "none"
+		if ts, ok := node["style"].(map[string]any); ok {
+			s.FontSize, _ = ts["fontSize"].(float64)
+			if weight, ok := ts["fontWeight"].(float64); ok {
+				s.Bold = weight >= 600
+			}
+		}
+		return s, true
+	}
+
+	s.Fill = fill
+	if s.Fill == "" {
+		s.Fill = "none"
+	}
+	if c, ok := firstPaint(node["strokes"]); ok {
+		s.Stroke = c
+		s.StrokeWidth, _ = node["strokeWeight"].(float64)
+	} else {
+		s.Stroke = "none"
+	}
+	if r, ok := node["cornerRadius"].(float64); ok && r > 0 {
+		s.Rounded, s.Radius = true, r
+	}
+	return s, false
+}
+
+// figmaMeta keeps the node type and text attributes that have no Style field,
+// as "figma.<field>" metadata.
+func figmaMeta(node map[string]any) map[string]string {
+	meta := map[string]string{"figma.type": str(node, "type")}
+	if ts, ok := node["style"].(map[string]any); ok {
+		for _, key := range []string{"fontFamily", "textAlignHorizontal"} {
+			if v, ok := ts[key].(string); ok {
+				meta["figma."+key] = v
+			}
+		}
+	}
+	return meta
+}
+
+func firstPaint(v any) (string, bool) {
+	paints, _ := v.([]any)
+	for _, p := range paints {
+		if paint, ok := p.(map[string]any); ok {
+			if c, ok := hexColor(paint["color"]); ok {
+				return c, true
+			}
+		}
+	}
+	return "", false
+}
+
+func figmaBounds(node map[string]any) (Rect, bool) {
+	box, ok := node["absoluteBoundingBox"].(map[string]any)
+	if !ok {
+		return Rect{}, false
+	}
+	var r Rect
+	for key, dst := range map[string]*float64{"x": &r.X, "y": &r.Y, "width": &r.Width, "height": &r.Height} {
+		f, ok := box[key].(float64)
+		if !ok {
+			return Rect{}, false
+		}
+		*dst = f
+	}
+	return r, true
+}
+
+func str(node map[string]any, key string) string {
+	s, _ := node[key].(string)
+	return s
+}
+
+// contains reports whether b lies entirely inside a (edges may touch).
+func contains(a, b Rect) bool {
+	return b.X >= a.X && b.Y >= a.Y && b.X+b.Width <= a.X+a.Width && b.Y+b.Height <= a.Y+a.Height
+}
+
+// ToFigma renders the document as Figma JSON in the builder's schema: a
+// single page with one frame is written as a single view, anything else as a
+// DOCUMENT with one CANVAS per page. Edges have no Figma equivalent and are
+// dropped. A labelled Draw.io shape becomes a RECTANGLE followed by a TEXT
+// sibling covering it, and a container becomes a FRAME named after its label.
+func ToFigma(d *Document) (string, error) {
+	var document map[string]any
+	if len(d.Pages) == 1 && len(d.Pages[0].Frames) == 1 {
+		// The builder schema requires the view's root frame to be "0:1"
+		document = figmaFrameNode(d.Pages[0], d.Pages[0].Frames[0])
+		document["id"] = "0:1"
+	} else {
+		var pages []any
+		for i, p := range d.Pages {
+			var frames []any
+			for _, f := range p.Frames {
+				frames = append(frames, figmaFrameNode(p, f))
+			}
+			id := p.ID
+			if id == "" {
+				id = fmt.Sprintf("%d:0", i+1)
+			}
+			pages = append(pages, map[string]any{"id": id, "name": p.Name, "type": "CANVAS", "children": frames})
+		}
+		document = map[string]any{"id": "0:0", "name": "Document", "type": "DOCUMENT", "children": pages}
+	}
+
+	out := struct {
+		Name          string         `json:"name,omitempty"`
+		SchemaVersion int            `json:"schemaVersion"`
+		Document      map[string]any `json:"document"`
+		Components    map[string]any `json:"components"`
+		Styles        map[string]any `json:"styles"`
+	}{d.Name, 0, document, map[string]any{}, map[string]any{}}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize Figma document: %w", err)
+	}
+	return string(data), nil
+}
+
+// figmaFrameNode renders a frame as a FRAME node. Draw.io layers have no size
+// of their own, so an unsized frame takes the page's extent.
+func figmaFrameNode(p *Page, f *Frame) map[string]any {
+	bounds := f.Bounds
+	if bounds.Width <= 0 || bounds.Height <= 0 {
+		w, h := p.Extent()
+		bounds.Width, bounds.Height = cmp.Or(bounds.Width, w), cmp.Or(bounds.Height, h)
+	}
+	node := map[string]any{
+		"id":                  f.ID,
+		"name":                cmp.Or(f.Name, p.Name),
+		"type":                "FRAME",
+		"visible":             true,
+		"absoluteBoundingBox": figmaBox(bounds),
+	}
+	if c, ok := figmaColor(f.Style.Fill); ok {
+		node["backgroundColor"] = c
+	}
+	node["children"] = figmaNodes(f.Widgets)
+	return node
+}
+
+func figmaNodes(ws []*Widget) []any {
+	nodes := []any{}
+	for _, w := range ws {
+		name := w.Name
+		if name == "" {
+			name = strings.Join(Lines(w.Label), " ")
+		}
+		if name == "" {
+			name = string(w.Kind)
+		}
+		node := map[string]any{
+			"id":                  w.ID,
+			"name":                name,
+			"visible":             true,
+			"absoluteBoundingBox": figmaBox(w.Geometry),
+		}
+
+		if w.Meta["figma.type"] == "TEXT" || (w.Kind == taxonomy.Text && len(w.Children) == 0 && w.Label != "") {
+			node["type"] = "TEXT"
+			node["characters"] = w.Text()
+			node["style"] = figmaTextStyle(w)
+			if c, ok := figmaColor(w.Style.FontColor); ok {
+				node["fills"] = []any{map[string]any{"type": "SOLID", "color": c}}
+			}
+			nodes = append(nodes, node)
+			continue
+		}
+
+		node["type"] = "RECTANGLE"
+		switch t := w.Meta["figma.type"]; {
+		case t == "FRAME" || t == "GROUP" || t == "COMPONENT" || t == "RECTANGLE":
+			node["type"] = t
+		case len(w.Children) > 0:
+			node["type"] = "FRAME"
+		}
+		// Draw.io's defaults are a white fill and a black stroke
+		fill, stroke := w.Style.Fill, w.Style.Stroke
+		if w.Meta["figma.type"] == "" {
+			fill, stroke = cmp.Or(fill, "#ffffff"), cmp.Or(stroke, "#000000")
+		}
+		if c, ok := figmaColor(fill); ok {
+			node["backgroundColor"] = c
+		}
+		if c, ok := figmaColor(stroke); ok {
+			node["strokes"] = []any{map[string]any{"type": "SOLID", "color": c, "opacity": 1}}
+			node["strokeWeight"] = max(w.Style.StrokeWidth, 1)
+		}
+		if w.Style.Radius > 0 {
+			node["cornerRadius"] = w.Style.Radius
+		} else if w.Style.Rounded {
+			node["cornerRadius"] = 6.0
+		}
+
+		// Children of a RECTANGLE follow it as siblings, offset into its space
+		var after []any
+		if node["type"] == "RECTANGLE" {
+			for _, c := range figmaNodes(w.Children) {
+				child := c.(map[string]any)
+				box := child["absoluteBoundingBox"].(map[string]any)
+				box["x"] = box["x"].(float64) + w.Geometry.X
+				box["y"] = box["y"].(float64) + w.Geometry.Y
+				after = append(after, child)
+			}
+		} else {
+			node["children"] = figmaNodes(w.Children)
+		}
+		nodes = append(nodes, node)
+
+		// Draw.io renders the value on the shape; Figma needs a TEXT node for
+		// it. Containers keep theirs as the frame name only.
+		if w.Label != "" && w.Meta["figma.type"] == "" && node["type"] == "RECTANGLE" {
+			label := map[string]any{
+				"id":                  w.ID + "-label",
+				"name":                name + " Label",
+				"type":                "TEXT",
+				"visible":             true,
+				"characters":          w.Text(),
+				"absoluteBoundingBox": figmaBox(w.Geometry),
+				"style":               figmaTextStyle(w),
+			}
+			if c, ok := figmaColor(w.Style.FontColor); ok {
+				label["fills"] = []any{map[string]any{"type": "SOLID", "color": c}}
+			}
+			nodes = append(nodes, label)
+		}
+		nodes = append(nodes, after...)
+	}
+	return nodes
+}
+
+func figmaTextStyle(w *Widget) map[string]any {
+	size := w.Style.FontSize
+	if size <= 0 {
+		size = DefaultFontSize
+	}
+	weight := 400.0
+	if w.Style.Bold {
+		weight = 700
+	}
+	style := map[string]any{"fontFamily": "Arial", "fontWeight": weight, "fontSize": size}
+	for k, v := range w.Meta {
+		if key, ok := strings.CutPrefix(k, "figma."); ok && key != "type" {
+			style[key] = v
+		}
+	}
+	return style
+}
+
+func figmaBox(r Rect) map[string]any {
+	return map[string]any{"x": r.X, "y": r.Y, "width": r.Width, "height": r.Height}
+}
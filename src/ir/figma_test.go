@@ -0,0 +1,130 @@
+package ir
+
+import (
+	"cmp"
+	"reflect"
+	"testing"
+)
+
+// builderFigma follows the Figma builder prompt: a root FRAME "0:1" with
+// relative coordinates, a section FRAME, an input drawn as a bordered
+// RECTANGLE with a placeholder TEXT over it, and a button.
+const builderFigma = `{
+  "schemaVersion": 0,
+  "document": {
+    "id": "0:1",
+    "name": "Login View",
+    "type": "FRAME",
+    "absoluteBoundingBox": {"x": 0, "y": 0, "width": 800, "height": 600},
+    "backgroundColor": {"r": 1, "g": 1, "b": 1, "a": 1},
+    "visible": true,
+    "children": [
+      {
+        "id": "0:2", "name": "Title", "type": "TEXT", "visible": true,
+        "absoluteBoundingBox": {"x": 40, "y": 40, "width": 300, "height": 32},
+        "characters": "Sign in",
+        "style": {"fontFamily": "Arial", "fontWeight": 700, "fontSize": 24}
+      },
+      {
+        "id": "0:3", "name": "Login Form", "type": "FRAME", "visible": true,
+        "absoluteBoundingBox": {"x": 40, "y": 100, "width": 400, "height": 200},
+        "children": [
+          {
+            "id": "0:4", "name": "Email Input", "type": "RECTANGLE", "visible": true,
+            "absoluteBoundingBox": {"x": 0, "y": 0, "width": 400, "height": 40},
+            "backgroundColor": {"r": 1, "g": 1, "b": 1, "a": 1},
+            "strokes": [{"type": "SOLID", "color": {"r": 0.8, "g": 0.8, "b": 0.8}, "opacity": 1}],
+            "strokeWeight": 1,
+            "cornerRadius": 4
+          },
+          {
+            "id": "0:5", "name": "Email Placeholder", "type": "TEXT", "visible": true,
+            "absoluteBoundingBox": {"x": 8, "y": 10, "width": 200, "height": 20},
+            "characters": "you@example.com",
+            "fills": [{"type": "SOLID", "color": {"r": 0.6, "g": 0.6, "b": 0.6}}],
+            "style": {"fontFamily": "Arial", "fontWeight": 400, "fontSize": 14, "textAlignHorizontal": "LEFT"}
+          },
+          {
+            "id": "0:6", "name": "Submit Button", "type": "RECTANGLE", "visible": true,
+            "absoluteBoundingBox": {"x": 0, "y": 80, "width": 120, "height": 44},
+            "backgroundColor": {"r": 0.1, "g": 0.6, "b": 0.9, "a": 1},
+            "cornerRadius": 6
+          }
+        ]
+      }
+    ]
+  },
+  "components": {},
+  "styles": {}
+}`
+
+func TestFigmaRoundTrip(t *testing.T) {
+	doc, err := FromFigma(builderFigma)
+	if err != nil {
+		t.Fatalf("FromFigma: %v", err)
+	}
+	page := doc.Pages[0]
+	form := page.Frames[0].Widgets[1]
+	if len(form.Children) != 2 || len(form.Children[0].Children) != 1 {
+		t.Fatalf("placeholder not nested in its input: %+v", form.Children)
+	}
+
+	out, err := ToFigma(doc)
+	if err != nil {
+		t.Fatalf("ToFigma: %v", err)
+	}
+	back, err := FromFigma(out)
+	if err != nil {
+		t.Fatalf("FromFigma of written JSON: %v\n%s", err, out)
+	}
+	if !reflect.DeepEqual(back, doc) {
+		t.Errorf("document changed in round trip:\ngot  %+v\nwant %+v\n%s", back.Pages[0], page, out)
+	}
+
+	for range 20 {
+		again, err := ToFigma(doc)
+		if err != nil {
+			t.Fatalf("ToFigma: %v", err)
+		}
+		if again != out {
+			t.Fatalf("output differs between runs:\n%s\n---\n%s", out, again)
+		}
+	}
+}
+
+// A Draw.io view converted to Figma keeps its widgets, labels and boxes;
+// containers carry their label as the frame name.
+func TestDrawioToFigma(t *testing.T) {
+	doc, err := FromDrawio(builderModel)
+	if err != nil {
+		t.Fatalf("FromDrawio: %v", err)
+	}
+	out, err := ToFigma(doc)
+	if err != nil {
+		t.Fatalf("ToFigma: %v", err)
+	}
+	back, err := FromFigma(out)
+	if err != nil {
+		t.Fatalf("FromFigma of written JSON: %v\n%s", err, out)
+	}
+
+	type box struct {
+		label string
+		abs   Rect
+	}
+	collect := func(p *Page) map[string]box {
+		got := make(map[string]box)
+		p.Walk(func(w *Widget, abs Rect, _ *Widget) {
+			if label := cmp.Or(w.Text(), w.Name); label != "" {
+				got[label] = box{label, abs}
+			}
+		})
+		return got
+	}
+	want, got := collect(doc.Pages[0]), collect(back.Pages[0])
+	for label, b := range want {
+		if got[label] != b {
+			t.Errorf("%q: got %+v, want %+v", label, got[label], b)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// src/ir/ir.go
+
+// Package ir is a format-neutral model of generated wireframes. Draw.io XML
+// and Figma JSON convert to and from it, so validators and exporters can be
+// written once against pages, frames and widgets instead of per format.
+package ir
+
+import "holoplan-cli/src/taxonomy"
+
+// Document is a set of pages, e.g. the views of a run.
+type Document struct {
+	Name  string
+	Pages []*Page
+}
+
+// Page is one view: a Draw.io diagram or a Figma CANVAS (or a single-view
+// Figma file). Width and Height are 0 when the source does not say.
+type Page struct {
+	ID     string
+	Name   string
+	Width  float64
+	Height float64
+	Frames []*Frame
+	Meta   map[string]string
+}
+
+// Frame is a top-level board on a page: a Draw.io layer or a Figma root FRAME.
+// Bounds is the frame's position on the page; its widgets are positioned
+// relative to Bounds' top-left corner.
+type Frame struct {
+	ID      string
+	Name    string
+	Bounds  Rect
+	Style   Style
+	Widgets []*Widget
+	Edges   []*Edge
+	Meta    map[string]string
+}
+
+// Widget is a visible element. Geometry is relative to the parent widget, or
+// to the frame for top-level widgets.
+type Widget struct {
+	ID       string
+	Kind     taxonomy.Kind
+	Name     string // designer-facing name (Figma node name); not rendered
+	Label    string // rendered text, may contain Draw.io HTML
+	Geometry Rect
+	Style    Style
+	Children []*Widget
+	Meta     map[string]string
+}
+
+// Edge is a connector between widgets. An unattached end is pinned by its point.
+type Edge struct {
+	ID          string
+	Label       string
+	Source      string
+	Target      string
+	Parent      string // parent widget when the connector lives inside a container
+	SourcePoint *Point
+	TargetPoint *Point
+	Waypoints   []Point
+	Style       Style
+	Meta        map[string]string
+}
+
+// Rect is an axis-aligned box.
+type Rect struct {
+	X, Y, Width, Height float64
+}
+
+// Point is a position in its owner's coordinate space.
+type Point struct {
+	X, Y float64
+}
+
+// Style holds the presentation attributes both formats share. Colors are
+// "#rrggbb", "none", or "" for the format's default. Radius is the corner
+// radius in px; Rounded with no Radius means the format's default rounding.
+// Raw keeps the original Draw.io style string so Draw.io round trips are
+// lossless.
+type Style struct {
+	Fill        string
+	Stroke      string
+	StrokeWidth float64
+	FontColor   string
+	FontSize    float64
+	Bold        bool
+	Rounded     bool
+	Radius      float64
+	Wrap        bool
+	Raw         string
+}
+
+// Walk calls fn for every widget of the frame in drawing order, parents before
+// children, with the widget's absolute position on the page.
+func (f *Frame) Walk(fn func(w *Widget, abs Rect, parent *Widget)) {
+	var visit func(ws []*Widget, parent *Widget, ox, oy float64)
+	visit = func(ws []*Widget, parent *Widget, ox, oy float64) {
+		for _, w := range ws {
+			abs := w.Geometry
+			abs.X += ox
+			abs.Y += oy
+			fn(w, abs, parent)
+			visit(w.Children, w, abs.X, abs.Y)
+		}
+	}
+	visit(f.Widgets, nil, f.Bounds.X, f.Bounds.Y)
+}
+
+// Walk calls fn for every widget of every frame on the page; see Frame.Walk.
+func (p *Page) Walk(fn func(w *Widget, abs Rect, parent *Widget)) {
+	for _, f := range p.Frames {
+		f.Walk(fn)
+	}
+}
+
+// Extent returns the page size: Width and Height when set, otherwise the
+// bottom-right corner of the furthest frame or widget.
+func (p *Page) Extent() (float64, float64) {
+	if p.Width > 0 && p.Height > 0 {
+		return p.Width, p.Height
+	}
+	w, h := p.Width, p.Height
+	for _, f := range p.Frames {
+		w = max(w, f.Bounds.X+f.Bounds.Width)
+		h = max(h, f.Bounds.Y+f.Bounds.Height)
+	}
+	p.Walk(func(_ *Widget, abs Rect, _ *Widget) {
+		w = max(w, abs.X+abs.Width)
+		h = max(h, abs.Y+abs.Height)
+	})
+	return w, h
+}
+
+// Text returns the widget's label with Draw.io HTML markup removed and line
+// breaks kept as "\n".
+func (w *Widget) Text() string {
+	return PlainText(w.Label)
+}
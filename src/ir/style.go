@@ -0,0 +1,164 @@
+// src/ir/style.go
+package ir
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"holoplan-cli/src/taxonomy"
+)
+
+// DefaultFontSize is Draw.io's font size when a style sets none.
+const DefaultFontSize = 12
+
+// drawioStyle reads the shared attributes out of a Draw.io style string. Text
+// cells ("text;...") have no fill or stroke unless the style sets one.
+func drawioStyle(raw string) Style {
+	styles := taxonomy.ParseStyle(raw)
+	s := Style{
+		Fill:      colorValue(styles["fillColor"]),
+		Stroke:    colorValue(styles["strokeColor"]),
+		FontColor: colorValue(styles["fontColor"]),
+		Rounded:   styles["rounded"] == "1",
+		Wrap:      styles["whiteSpace"] == "wrap",
+		Raw:       raw,
+	}
+	if _, text := styles["text"]; text {
+		if s.Fill == "" {
+			s.Fill = "none"
+		}
+		if s.Stroke == "" {
+			s.Stroke = "none"
+		}
+	}
+	s.StrokeWidth, _ = strconv.ParseFloat(styles["strokeWidth"], 64)
+	s.FontSize, _ = strconv.ParseFloat(styles["fontSize"], 64)
+	// arcSize is a percentage of the shorter side unless absoluteArcSize=1
+	if s.Rounded && styles["absoluteArcSize"] == "1" {
+		s.Radius, _ = strconv.ParseFloat(styles["arcSize"], 64)
+	}
+	if fs, err := strconv.Atoi(styles["fontStyle"]); err == nil {
+		s.Bold = fs&1 != 0
+	}
+	return s
+}
+
+// colorValue normalizes a Draw.io color to "#rrggbb", "none" or "".
+func colorValue(v string) string {
+	v = strings.TrimSpace(v)
+	switch {
+	case v == "" || strings.EqualFold(v, "default"):
+		return ""
+	case strings.EqualFold(v, "none"):
+		return "none"
+	}
+	if r, g, b, ok := ParseHex(v); ok {
+		return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+	}
+	return v
+}
+
+// DrawioStyle returns the Draw.io style string for a widget: the original one
+// when the widget came from Draw.io, otherwise one built from its attributes.
+// Widgets whose text is only a designer-facing name get noLabel=1.
+func (w *Widget) DrawioStyle() string {
+	if w.Style.Raw != "" {
+		return w.Style.Raw
+	}
+
+	var parts []string
+	if w.Kind == taxonomy.Text {
+		parts = append(parts, "text")
+	}
+	if w.Kind != taxonomy.Unknown {
+		parts = append(parts, "kind="+string(w.Kind))
+	}
+	if w.Label == "" && w.Name != "" {
+		parts = append(parts, "noLabel=1")
+	}
+	parts = append(parts, w.Style.drawio()...)
+	return strings.Join(parts, ";")
+}
+
+// drawio renders the attributes as Draw.io style entries.
+func (s Style) drawio() []string {
+	var parts []string
+	if s.Rounded {
+		parts = append(parts, "rounded=1")
+		if s.Radius > 0 {
+			parts = append(parts, "absoluteArcSize=1", "arcSize="+formatNum(s.Radius))
+		}
+	}
+	if s.Wrap {
+		parts = append(parts, "whiteSpace=wrap")
+	}
+	for _, kv := range [][2]string{{"fillColor", s.Fill}, {"strokeColor", s.Stroke}, {"fontColor", s.FontColor}} {
+		if kv[1] != "" {
+			parts = append(parts, kv[0]+"="+kv[1])
+		}
+	}
+	if s.StrokeWidth > 0 {
+		parts = append(parts, "strokeWidth="+formatNum(s.StrokeWidth))
+	}
+	if s.FontSize > 0 {
+		parts = append(parts, "fontSize="+formatNum(s.FontSize))
+	}
+	if s.Bold {
+		parts = append(parts, "fontStyle=1")
+	}
+	return parts
+}
+
+// ParseHex reads "#rgb" or "#rrggbb".
+func ParseHex(v string) (r, g, b uint8, ok bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "#")
+	if len(v) == 3 {
+		v = string([]byte{v[0], v[0], v[1], v[1], v[2], v[2]})
+	}
+	if len(v) != 6 {
+		return 0, 0, 0, false
+	}
+	n, err := strconv.ParseUint(v, 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return uint8(n >> 16), uint8(n >> 8), uint8(n), true
+}
+
+// figmaColor converts "#rrggbb" to a Figma {r, g, b, a} color.
+func figmaColor(hex string) (map[string]any, bool) {
+	r, g, b, ok := ParseHex(hex)
+	if !ok {
+		return nil, false
+	}
+	ch := func(v uint8) float64 { return math.Round(float64(v)/255*1000) / 1000 }
+	return map[string]any{"r": ch(r), "g": ch(g), "b": ch(b), "a": 1.0}, true
+}
+
+// hexColor converts a Figma {r, g, b[, a]} color to "#rrggbb"; a fully
+// transparent color is "none".
+func hexColor(v any) (string, bool) {
+	c, ok := v.(map[string]any)
+	if !ok {
+		return "", false
+	}
+	var rgb [3]uint8
+	for i, ch := range []string{"r", "g", "b"} {
+		f, ok := c[ch].(float64)
+		if !ok {
+			return "", false
+		}
+		rgb[i] = uint8(math.Round(min(max(f, 0), 1) * 255))
+	}
+	if a, ok := c["a"].(float64); ok && a == 0 {
+		return "none", true
+	}
+	return fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2]), true
+}
+
+// formatNum renders a coordinate without trailing zeros.
+func formatNum(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,31 @@
+// src/ir/text.go
+package ir
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	lineBreaks = regexp.MustCompile(`(?i)<br\s*/?>|</(div|p|li)>`)
+	htmlTags   = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Lines turns a label into its visible lines of text, honouring the line
+// breaks of Draw.io html=1 labels. Blank lines are dropped.
+func Lines(label string) []string {
+	var lines []string
+	for _, line := range strings.Split(PlainText(label), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// PlainText strips HTML markup from a label, keeping line breaks as "\n".
+func PlainText(label string) string {
+	text := lineBreaks.ReplaceAllString(label, "\n")
+	return html.UnescapeString(htmlTags.ReplaceAllString(text, ""))
+}
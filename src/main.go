@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 
+	"holoplan-cli/src/config"
 	"holoplan-cli/src/runner"
 
 	"github.com/spf13/cobra"
@@ -18,8 +19,17 @@ func main() {
 		Short: "Holoplan generates UI wireframes from user stories",
 	}
 
+	var configPath string
 	var storiesPath string
 	var format string
+	var resume bool
+	var validateOutput string
+	var viewType string
+	var repair bool
+	var fixMode string
+	var dryRun bool
+	var scale float64
+	var graphOutput string
 
 	var runCmd = &cobra.Command{
 		Use:   "run",
@@ -36,7 +46,19 @@ func main() {
 				storiesPath = strings.TrimSpace(input)
 			}
 
-			if err := runner.RunPipeline(storiesPath, format); err != nil {
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				fmt.Println("[x] Failed to load config:", err)
+				os.Exit(1)
+			}
+
+			if err := runner.RunPipeline(runner.Options{
+				StoriesPath: storiesPath,
+				Format:      format,
+				Resume:      resume,
+				FixMode:     fixMode,
+				Config:      cfg,
+			}); err != nil {
 				fmt.Println("[x] Pipeline failed:", err)
 				os.Exit(1)
 			}
@@ -44,9 +66,101 @@ func main() {
 	}
 
 	runCmd.Flags().StringVarP(&storiesPath, "stories", "s", "", "Path to user stories YAML file")
-	runCmd.Flags().StringVarP(&format, "format", "f", "drawio", "Output format: drawio or figma")
+	runCmd.Flags().StringVarP(&format, "format", "f", "drawio", "Output format: drawio, figma, html (a clickable prototype), excalidraw, salt (PlantUML) or bmml (Balsamiq)")
+	runCmd.Flags().BoolVar(&resume, "resume", false, "Resume an interrupted run from output/.holoplan_state.json")
+	runCmd.Flags().StringVar(&fixMode, "fix", runner.FixBefore, "Deterministic layout fixes: before (the LLM resolver), instead (of it) or off")
+
+	var validateCmd = &cobra.Command{
+		Use:   "validate <file.drawio>...",
+		Short: "Validate the layout of existing Draw.io files, page by page",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "[x] Failed to load config:", err)
+				os.Exit(1)
+			}
+
+			if err := runner.RunValidate(args, validateOutput, viewType, repair, cfg); err != nil {
+				fmt.Fprintln(os.Stderr, "[x] Validation failed:", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	validateCmd.Flags().StringVarP(&validateOutput, "output", "o", "text", "Report format: text or json")
+	validateCmd.Flags().StringVar(&viewType, "view-type", "", "Apply rule overrides for this view type (e.g. modal)")
+	validateCmd.Flags().BoolVar(&repair, "repair", false, "Fix structural problems (missing root cells, duplicate IDs, dangling parents) in place")
+
+	var fixCmd = &cobra.Command{
+		Use:   "fix <file.drawio>...",
+		Short: "Apply deterministic layout fixes to existing Draw.io files",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "[x] Failed to load config:", err)
+				os.Exit(1)
+			}
+
+			if err := runner.RunFix(args, viewType, dryRun, cfg); err != nil {
+				fmt.Fprintln(os.Stderr, "[x] Fix incomplete:", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	fixCmd.Flags().StringVar(&viewType, "view-type", "", "Apply rule overrides for this view type (e.g. modal)")
+	fixCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report the fixes without writing the files")
+
+	var previewCmd = &cobra.Command{
+		Use:   "preview <file.drawio|file.figma.json>...",
+		Short: "Render SVG and PNG previews of existing layouts next to the files",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "[x] Failed to load config:", err)
+				os.Exit(1)
+			}
+			if cmd.Flags().Changed("scale") {
+				cfg.Preview.Scale = scale
+				if err := cfg.Preview.Check(); err != nil {
+					fmt.Fprintln(os.Stderr, "[x] Invalid --scale:", err)
+					os.Exit(1)
+				}
+			}
+
+			if err := runner.RunPreview(args, cfg.Preview); err != nil {
+				fmt.Fprintln(os.Stderr, "[x] Preview failed:", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	previewCmd.Flags().Float64Var(&scale, "scale", 1, "PNG pixels per layout pixel (overrides preview.scale)")
+
+	var graphCmd = &cobra.Command{
+		Use:   "graph <stories.yaml>",
+		Short: "Write the stories' view navigation as a Mermaid flowchart",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runner.RunGraph(args[0], graphOutput); err != nil {
+				fmt.Fprintln(os.Stderr, "[x] Graph failed:", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	graphCmd.Flags().StringVarP(&graphOutput, "output", "o", "output/navigation.mmd", "Output file; a .md file gets the chart in a mermaid block")
+
+	rootCmd.PersistentFlags().StringVarP(&configPath, "config", "c", config.DefaultPath, "Path to holoplan config file")
 
 	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(fixCmd)
+	rootCmd.AddCommand(previewCmd)
+	rootCmd.AddCommand(graphCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println("[x] Command execution failed:", err)
@@ -0,0 +1,102 @@
+// src/runner/canvas.go
+package runner
+
+import (
+	"fmt"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"holoplan-cli/src/export"
+	"holoplan-cli/src/types"
+)
+
+// canvasPath is the story map written with every run.
+const canvasPath = "output/stories.canvas"
+
+// writeCanvas writes the run's story map as a JSON Canvas: each story's
+// narrative and saved views, embedded by their previews, with arrows along
+// the stories' navigation and through the views planned for each story.
+// File nodes hold paths relative to the working directory, which canvas apps
+// such as Obsidian resolve when it is the vault root.
+func writeCanvas(path, format string, stories []types.UserStory, state *RunState) error {
+	ext := ".drawio"
+	if layoutFormat(format) == "figma" {
+		ext = ".figma.json"
+	}
+	views, err := savedViews(stories, state, ext)
+	if err != nil {
+		return fmt.Errorf("failed to scan output files: %w", err)
+	}
+
+	var entries []export.CanvasStory
+	for _, story := range stories {
+		entry := export.CanvasStory{ID: story.ID, Title: story.Title, Narrative: story.Narrative}
+		for _, v := range views {
+			if v.Story.ID == story.ID {
+				entry.Views = append(entry.Views, canvasView(v))
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	var edges []export.CanvasEdge
+	steps := make(map[[2]string]int) // from/to bases → index in edges
+	step := func(from, to savedView, label string) {
+		if from.Base == to.Base {
+			return
+		}
+		k := [2]string{from.Base, to.Base}
+		if i, ok := steps[k]; ok {
+			if label != "" && !strings.Contains(", "+edges[i].Label+", ", ", "+label+", ") {
+				edges[i].Label = strings.TrimPrefix(edges[i].Label+", "+label, ", ")
+			}
+			return
+		}
+		steps[k] = len(edges)
+		edges = append(edges, export.CanvasEdge{From: from.Base, To: to.Base, Label: label})
+	}
+	// Stories the chunker split into several views step through them in order
+	for i := 1; i < len(views); i++ {
+		if views[i].Story.ID == views[i-1].Story.ID {
+			step(views[i-1], views[i], "")
+		}
+	}
+	index := viewIndex(views, state)
+	for _, l := range navigation(stories, state) {
+		from, ok := index[viewKey(l.From)]
+		to, ok2 := index[viewKey(l.To)]
+		if ok && ok2 {
+			step(from, to, l.Story.ID)
+		}
+	}
+
+	canvas, err := export.Canvas(entries, edges)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, canvas, 0644); err != nil {
+		return fmt.Errorf("failed to write canvas: %w", err)
+	}
+	return nil
+}
+
+// canvasView embeds a saved view by its PNG preview, sized as the image, or
+// else its SVG preview; a view with neither is a card with its name.
+func canvasView(v savedView) export.CanvasView {
+	cv := export.CanvasView{ID: v.Base, Name: v.View.Name}
+	if f, err := os.Open(filepath.Join("output", v.Base+".png")); err == nil {
+		defer f.Close()
+		if cfg, err := png.DecodeConfig(f); err == nil {
+			cv.File = filepath.ToSlash(f.Name())
+			cv.Width, cv.Height = float64(cfg.Width), float64(cfg.Height)
+			return cv
+		}
+	}
+	svg := filepath.Join("output", v.Base+".svg")
+	if _, err := os.Stat(svg); err == nil {
+		cv.File = filepath.ToSlash(svg)
+	}
+	return cv
+}
@@ -0,0 +1,139 @@
+// src/runner/export.go
+package runner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"holoplan-cli/src/export"
+	"holoplan-cli/src/ir"
+	"holoplan-cli/src/types"
+)
+
+const (
+	// excalidrawPath is the combined scene of every view.
+	excalidrawPath = "output/final.excalidraw"
+	// saltPath holds every view's Salt diagram.
+	saltPath = "output/final.puml"
+)
+
+// exportOutput writes the files of an export format from the run's saved
+// Draw.io views. The native formats (drawio, figma) have nothing to export.
+func exportOutput(format string, stories []types.UserStory, state *RunState) error {
+	switch format {
+	case "html":
+		return exportHTML(stories, state)
+	case "excalidraw":
+		return exportExcalidraw(stories, state)
+	case "salt":
+		return exportSalt(stories, state)
+	case "bmml":
+		return exportBMML(stories, state)
+	}
+	return nil
+}
+
+// loadViews reads the run's saved Draw.io views in story order, each as its
+// first page named after the view's file.
+func loadViews(stories []types.UserStory, state *RunState) ([]savedView, []*ir.Page, error) {
+	views, err := savedViews(stories, state, ".drawio")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to scan output files: %w", err)
+	}
+	if len(views) == 0 {
+		return nil, nil, fmt.Errorf("no .drawio files found in output directory")
+	}
+
+	pages := make([]*ir.Page, len(views))
+	for i, v := range views {
+		content, err := os.ReadFile(v.Path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", v.Path, err)
+		}
+		doc, err := ir.FromDrawio(string(content))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s: %w", v.Path, err)
+		}
+		if len(doc.Pages) == 0 {
+			return nil, nil, fmt.Errorf("no diagram found in %s", v.Path)
+		}
+		pages[i] = doc.Pages[0]
+		pages[i].Name = v.Base
+	}
+	return views, pages, nil
+}
+
+// exportExcalidraw writes an .excalidraw scene per saved view and a combined
+// scene with every view in its own frame.
+func exportExcalidraw(stories []types.UserStory, state *RunState) error {
+	views, pages, err := loadViews(stories, state)
+	if err != nil {
+		return err
+	}
+
+	for i, v := range views {
+		scene, err := export.Excalidraw(pages[i])
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join("output", v.Base+".excalidraw"), scene, 0644); err != nil {
+			return fmt.Errorf("failed to write Excalidraw scene: %w", err)
+		}
+	}
+
+	combined, err := export.ExcalidrawDocument(&ir.Document{Name: "holoplan", Pages: pages})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(excalidrawPath, combined, 0644); err != nil {
+		return fmt.Errorf("failed to write Excalidraw scene: %w", err)
+	}
+	fmt.Printf("✏️  Excalidraw scenes written (%d views, combined in %s)\n", len(views), excalidrawPath)
+	return nil
+}
+
+// exportSalt writes a PlantUML Salt wireframe per saved view and one file
+// with every view's diagram.
+func exportSalt(stories []types.UserStory, state *RunState) error {
+	views, pages, err := loadViews(stories, state)
+	if err != nil {
+		return err
+	}
+
+	titled := make([]*ir.Page, len(pages))
+	for i, v := range views {
+		title := fmt.Sprintf("%s · %s", v.Story.ID, v.View.Name)
+		if err := os.WriteFile(filepath.Join("output", v.Base+".puml"), export.Salt(pages[i], title), 0644); err != nil {
+			return fmt.Errorf("failed to write Salt wireframe: %w", err)
+		}
+		page := *pages[i]
+		page.Name = title
+		titled[i] = &page
+	}
+	if err := os.WriteFile(saltPath, export.SaltDocument(&ir.Document{Pages: titled}), 0644); err != nil {
+		return fmt.Errorf("failed to write Salt wireframe: %w", err)
+	}
+	fmt.Printf("🧂 Salt wireframes written (%d views, all in %s)\n", len(views), saltPath)
+	return nil
+}
+
+// exportBMML writes a Balsamiq mockup per saved view, each in a browser
+// window titled after its story and view. Views have no real address, so the
+// address bar is left blank.
+func exportBMML(stories []types.UserStory, state *RunState) error {
+	views, pages, err := loadViews(stories, state)
+	if err != nil {
+		return err
+	}
+
+	for i, v := range views {
+		title := fmt.Sprintf("%s · %s", v.Story.ID, v.View.Name)
+		mockup := export.BMML(pages[i], title, "")
+		if err := os.WriteFile(filepath.Join("output", v.Base+".bmml"), mockup, 0644); err != nil {
+			return fmt.Errorf("failed to write Balsamiq mockup: %w", err)
+		}
+	}
+	fmt.Printf("📐 Balsamiq mockups written (%d views)\n", len(views))
+	return nil
+}
@@ -0,0 +1,106 @@
+// src/runner/figma.go
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"holoplan-cli/src/types"
+)
+
+// figmaFile is the top level of a merged Figma document. A struct keeps the
+// fields in the order Figma's REST API returns them.
+type figmaFile struct {
+	Name          string         `json:"name"`
+	SchemaVersion int            `json:"schemaVersion"`
+	Document      map[string]any `json:"document"`
+	Components    map[string]any `json:"components"`
+	Styles        map[string]any `json:"styles"`
+}
+
+// mergeFigma builds one Figma document with a CANVAS page per saved view,
+// named after its story and view and ordered by the stories file and each
+// story's view plan. Node IDs are renumbered "<page>:<n>" so they stay unique
+// across the file.
+func mergeFigma(outputPath string, stories []types.UserStory, state *RunState) error {
+	merged := figmaFile{
+		Name:       "holoplan",
+		Components: map[string]any{},
+		Styles:     map[string]any{},
+	}
+	var pages []any
+
+	views, err := savedViews(stories, state, ".figma.json")
+	if err != nil {
+		return fmt.Errorf("failed to scan output files: %w", err)
+	}
+	for _, v := range views {
+		content, err := os.ReadFile(v.Path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", v.Path, err)
+		}
+
+		var sub map[string]any
+		if err := json.Unmarshal(content, &sub); err != nil {
+			return fmt.Errorf("failed to parse JSON in %s: %w", v.Path, err)
+		}
+		root, ok := sub["document"].(map[string]any)
+		if !ok {
+			return fmt.Errorf("no \"document\" node found in %s", v.Path)
+		}
+
+		page := len(pages) + 1
+		next := 1
+		renumberFigma(root, page, &next)
+
+		pages = append(pages, map[string]any{
+			"id":       fmt.Sprintf("%d:0", page),
+			"name":     fmt.Sprintf("%s · %s", v.Story.ID, v.View.Name),
+			"type":     "CANVAS",
+			"children": []any{root},
+		})
+
+		// Builder output rarely defines any, but keep them without clobbering
+		for key, dst := range map[string]map[string]any{"components": merged.Components, "styles": merged.Styles} {
+			defs, _ := sub[key].(map[string]any)
+			for k, def := range defs {
+				if _, taken := dst[k]; taken {
+					k = fmt.Sprintf("%d:%s", page, k)
+				}
+				dst[k] = def
+			}
+		}
+	}
+
+	if len(pages) == 0 {
+		return fmt.Errorf("no saved .figma.json views found in output directory")
+	}
+
+	merged.Document = map[string]any{
+		"id":       "0:0",
+		"name":     "Document",
+		"type":     "DOCUMENT",
+		"children": pages,
+	}
+
+	data, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize merged Figma document: %w", err)
+	}
+	return os.WriteFile(outputPath, data, 0644)
+}
+
+// renumberFigma gives a node and its descendants the IDs "<page>:<n>" in
+// depth-first order, so the view's root frame becomes "<page>:1".
+func renumberFigma(node map[string]any, page int, next *int) {
+	node["id"] = fmt.Sprintf("%d:%d", page, *next)
+	*next++
+
+	children, _ := node["children"].([]any)
+	for _, c := range children {
+		if child, ok := c.(map[string]any); ok {
+			renumberFigma(child, page, next)
+		}
+	}
+}
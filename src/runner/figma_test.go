@@ -0,0 +1,98 @@
+package runner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"holoplan-cli/src/types"
+	"holoplan-cli/src/validator"
+)
+
+// figmaView is a builder's Figma output for one view: a frame holding a
+// button and its label.
+const figmaView = `{"schemaVersion": 0, "components": {}, "styles": {"S:1": {"name": "Primary"}},
+"document": {"id": "0:1", "name": "View", "type": "FRAME", "visible": true,
+  "absoluteBoundingBox": {"x": 0, "y": 0, "width": 400, "height": 300},
+  "children": [
+    {"id": "0:2", "name": "Save", "type": "RECTANGLE", "visible": true, "absoluteBoundingBox": {"x": 20, "y": 20, "width": 120, "height": 44}},
+    {"id": "0:3", "name": "Save label", "type": "TEXT", "visible": true, "characters": "Save", "absoluteBoundingBox": {"x": 20, "y": 20, "width": 120, "height": 44}}
+  ]}}`
+
+func TestMergeFigma(t *testing.T) {
+	t.Chdir(t.TempDir())
+	stories := []types.UserStory{{ID: "US-2"}, {ID: "US-1"}}
+	state := newRunState("stories.yaml", "figma")
+	state.story("US-2").Plan = &types.ViewPlan{StoryID: "US-2", Views: []types.ViewLayout{{Name: "Search"}}}
+	state.story("US-1").Plan = &types.ViewPlan{StoryID: "US-1", Views: []types.ViewLayout{{Name: "Home"}, {Name: "Login"}}}
+	state.view("US-2", "Search").Saved = true
+	state.view("US-1", "Home").Saved = true
+	state.view("US-1", "Login").Saved = true
+
+	if err := os.MkdirAll("output", 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, base := range []string{"us-2_search", "us-1_home", "us-1_login"} {
+		if err := os.WriteFile(filepath.Join("output", base+".figma.json"), []byte(figmaView), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := filepath.Join("output", "final.figma.json")
+	if err := mergeFigma(path, stories, state); err != nil {
+		t.Fatalf("mergeFigma: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var merged struct {
+		Document struct {
+			Type     string `json:"type"`
+			Children []struct {
+				ID       string           `json:"id"`
+				Name     string           `json:"name"`
+				Type     string           `json:"type"`
+				Children []map[string]any `json:"children"`
+			} `json:"children"`
+		} `json:"document"`
+		Styles map[string]any `json:"styles"`
+	}
+	if err := json.Unmarshal(data, &merged); err != nil {
+		t.Fatal(err)
+	}
+
+	var names, ids []string
+	for _, p := range merged.Document.Children {
+		names = append(names, p.Name)
+		ids = append(ids, p.ID)
+		for _, frame := range p.Children {
+			ids = append(ids, frame["id"].(string))
+			for _, c := range frame["children"].([]any) {
+				ids = append(ids, c.(map[string]any)["id"].(string))
+			}
+		}
+	}
+	if want := []string{"US-2 · Search", "US-1 · Home", "US-1 · Login"}; !slices.Equal(names, want) {
+		t.Errorf("pages = %q, want %q", names, want)
+	}
+	wantIDs := []string{"1:0", "1:1", "1:2", "1:3", "2:0", "2:1", "2:2", "2:3", "3:0", "3:1", "3:2", "3:3"}
+	if !slices.Equal(ids, wantIDs) {
+		t.Errorf("ids = %v, want %v", ids, wantIDs)
+	}
+	if len(merged.Styles) != 3 || merged.Styles["S:1"] == nil || merged.Styles["3:S:1"] == nil {
+		t.Errorf("styles = %v, want S:1 kept and later copies prefixed by page", merged.Styles)
+	}
+
+	results, err := validator.CheckFigmaFile(path, validator.Rules{FigmaSchema: validator.DefaultRules().FigmaSchema})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if len(r.Diagnostics) > 0 {
+			t.Errorf("page %s: %v", r.Page, r.Diagnostics)
+		}
+	}
+}
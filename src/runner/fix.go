@@ -0,0 +1,100 @@
+// src/runner/fix.go
+package runner
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"holoplan-cli/src/config"
+	"holoplan-cli/src/fixer"
+	"holoplan-cli/src/validator"
+)
+
+// Fix modes for the pipeline's deterministic fix stage.
+const (
+	FixBefore  = "before"  // fix, then let the LLM resolve what is left
+	FixInstead = "instead" // fix and skip the LLM resolver
+	FixOff     = "off"
+)
+
+// RunFix applies the deterministic layout fixes to existing .drawio files and
+// writes them back, unless dryRun is set. It fails if any page still has
+// errors after fixing.
+func RunFix(paths []string, viewType string, dryRun bool, cfg config.Config) error {
+	rules, err := cfg.Rules.ForView(viewType)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", path, err)
+			failed++
+			continue
+		}
+
+		fixed, results, err := fixer.FixDocument(string(data), rules)
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", path, err)
+			failed++
+			continue
+		}
+
+		changed := false
+		for _, r := range results {
+			for _, fix := range r.Applied {
+				fmt.Printf("🛠️  %s [%s]: %s\n", path, r.Page, fix)
+				changed = true
+			}
+			if validator.HasErrors(r.Remaining) {
+				failed++
+			}
+			printPageResult(path, validator.PageResult{Page: r.Page, Diagnostics: r.Remaining})
+		}
+
+		switch {
+		case !changed:
+			fmt.Printf("✅ %s: nothing to fix\n", path)
+		case dryRun:
+			fmt.Printf("📝 %s: dry run, not written\n", path)
+		default:
+			if err := os.WriteFile(path, []byte(fixed), 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", path, err)
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d page(s) still fail validation", failed)
+	}
+	return nil
+}
+
+// autoFix runs the deterministic fixer on one view and returns the fixed
+// layout with the fixes applied, or the input unchanged if it cannot be
+// processed.
+func autoFix(xml string, rules validator.Rules) (string, []string) {
+	res, err := fixer.Fix(xml, rules)
+	if err != nil {
+		log.Printf("⚠️ Auto-fix skipped: %v", err)
+		return xml, nil
+	}
+	for _, fix := range res.Applied {
+		fmt.Printf("🛠️  %s\n", fix)
+	}
+	if len(res.Applied) > 0 {
+		fmt.Printf("🛠️  Auto-fix applied %d change(s), %d issue(s) remain\n", len(res.Applied), len(res.Remaining))
+	}
+	return res.XML, res.Applied
+}
+
+func checkFixMode(mode string) error {
+	switch mode {
+	case FixBefore, FixInstead, FixOff:
+		return nil
+	}
+	return fmt.Errorf("unknown fix mode %q (want before, instead or off)", mode)
+}
@@ -0,0 +1,126 @@
+// src/runner/graph.go
+package runner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"holoplan-cli/src/export"
+	"holoplan-cli/src/types"
+)
+
+// navigationPath is the navigation flowchart written with every run.
+const navigationPath = "output/navigation.mmd"
+
+// RunGraph writes the navigation flowchart of a stories file to out, as a
+// bare Mermaid file or, for a .md path, a Markdown file with the chart in a
+// mermaid block. Stories that declare no views take them from the last run's
+// checkpoint, if there is one.
+func RunGraph(storiesPath, out string) error {
+	stories, err := loadStories(storiesPath)
+	if err != nil {
+		return fmt.Errorf("failed to load stories: %w", err)
+	}
+	state, err := loadRunState(stateFile)
+	if err != nil {
+		return fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+	if err := writeNavigation(out, stories, state); err != nil {
+		return err
+	}
+	fmt.Printf("🧭 Navigation graph written to %s\n", out)
+	return nil
+}
+
+// writeNavigation writes the stories' navigation flowchart to path.
+func writeNavigation(path string, stories []types.UserStory, state *RunState) error {
+	chart := navigationChart(stories, state)
+	if strings.EqualFold(filepath.Ext(path), ".md") {
+		chart = export.MermaidMarkdown(chart)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+	if err := os.WriteFile(path, chart, 0644); err != nil {
+		return fmt.Errorf("failed to write navigation graph: %w", err)
+	}
+	return nil
+}
+
+// navigationChart builds the flowchart of every story's views and the
+// navigation between them. A view is one node however the stories spell its
+// name; a story's first view is an entry point unless it has an interaction
+// origin, and each step is labelled with the stories that take it. A story
+// with no views declared or planned stands in for them with a node of its
+// own, so it still shows where it starts and leads.
+func navigationChart(stories []types.UserStory, state *RunState) []byte {
+	var views []export.FlowView
+	nodes := make(map[string]int) // view key → index in views
+	node := func(name string) string {
+		k := viewKey(name)
+		if _, ok := nodes[k]; !ok {
+			nodes[k] = len(views)
+			views = append(views, export.FlowView{ID: "v_" + k, Label: name})
+		}
+		return "v_" + k
+	}
+
+	var edges []export.FlowEdge
+	steps := make(map[[2]string]int) // from/to IDs → index in edges
+	step := func(from, to, storyID string) {
+		k := [2]string{from, to}
+		if i, ok := steps[k]; ok {
+			if !strings.Contains(", "+edges[i].Label+", ", ", "+storyID+", ") {
+				edges[i].Label += ", " + storyID
+			}
+			return
+		}
+		steps[k] = len(edges)
+		edges = append(edges, export.FlowEdge{From: from, To: to, Label: storyID})
+	}
+
+	for _, story := range stories {
+		names := storyViews(story, planOf(state, story.ID))
+		if len(names) == 0 {
+			id := "s_" + strings.Map(func(r rune) rune {
+				if r < 0x80 && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+					return r
+				}
+				return '_'
+			}, story.ID)
+			views = append(views, export.FlowView{ID: id, Label: strings.TrimSuffix(story.ID+" · "+story.Title, " · "), Story: true})
+			if viewKey(story.InteractionOrigin) != "" {
+				step(node(story.InteractionOrigin), id, story.ID)
+			}
+			if viewKey(story.ResultingView) != "" {
+				step(id, node(story.ResultingView), story.ID)
+			}
+			continue
+		}
+		if viewKey(story.InteractionOrigin) != "" {
+			node(story.InteractionOrigin)
+		}
+		for i, name := range names {
+			if viewKey(name) == "" {
+				continue
+			}
+			node(name)
+			if i == 0 && viewKey(story.InteractionOrigin) == "" {
+				views[nodes[viewKey(name)]].Entry = true
+			}
+		}
+		if viewKey(story.ResultingView) != "" {
+			node(story.ResultingView)
+		}
+	}
+
+	for _, l := range navigation(stories, state) {
+		step(node(l.From), node(l.To), l.Story.ID)
+	}
+	return export.Mermaid(views, edges)
+}
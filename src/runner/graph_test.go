@@ -0,0 +1,45 @@
+package runner
+
+import (
+	"strings"
+	"testing"
+
+	"holoplan-cli/src/types"
+)
+
+func TestNavigationChart(t *testing.T) {
+	stories := []types.UserStory{
+		{ID: "US-1", Views: []string{"Home", "Product List"}, ResultingView: "Product Detail"},
+		{ID: "US-2", InteractionOrigin: "product_list", View: "Product Detail", ResultingView: "Cart"},
+		{ID: "US-3", Title: "Checkout", InteractionOrigin: "Cart"},
+		{ID: "US-4", Views: []string{"home", "product-list"}},
+		{ID: "US-5"},
+	}
+	state := newRunState("stories.yaml", "drawio")
+	state.story("US-5").Plan = &types.ViewPlan{StoryID: "US-5", Views: []types.ViewLayout{{Name: "Settings"}}}
+
+	want := `flowchart LR
+  v_home["Home"]
+  v_productlist["Product List"]
+  v_productdetail["Product Detail"]
+  v_cart["Cart"]
+  s_US_3(["US-3 · Checkout"])
+  v_settings["Settings"]
+  v_cart -->|"US-3"| s_US_3
+  v_home -->|"US-1, US-4"| v_productlist
+  v_productlist -->|"US-1, US-2"| v_productdetail
+  v_productdetail -->|"US-2"| v_cart
+  classDef entry fill:#dae8fc,stroke:#6c8ebf,stroke-width:2px
+  class v_home,v_settings entry
+  classDef story fill:#fff,stroke:#999,stroke-dasharray:4 3,color:#666
+  class s_US_3 story
+`
+	if got := string(navigationChart(stories, state)); got != want {
+		t.Errorf("chart:\n%s\nwant:\n%s", got, want)
+	}
+
+	// without a checkpoint, US-5 has no views and stands in for them
+	if got := string(navigationChart(stories, nil)); !strings.Contains(got, `s_US_5(["US-5"])`) {
+		t.Errorf("chart without a checkpoint has no stand-in for US-5:\n%s", got)
+	}
+}
@@ -0,0 +1,88 @@
+// src/runner/html.go
+package runner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"holoplan-cli/src/export"
+	"holoplan-cli/src/types"
+)
+
+// prototypeIndex is the landing page of the HTML prototype.
+const prototypeIndex = "output/index.html"
+
+// exportHTML writes a clickable prototype of the run's saved Draw.io views:
+// one page per view, linked along each story's navigation (interaction
+// origin → views → resulting view) and through the views planned for it,
+// plus an index page listing every story and its views.
+func exportHTML(stories []types.UserStory, state *RunState) error {
+	views, pages, err := loadViews(stories, state)
+	if err != nil {
+		return err
+	}
+
+	links := make(map[string][]export.Link)
+	linked := make(map[[2]string]bool)
+	link := func(from, to savedView, target string) {
+		if from.Base == to.Base || linked[[2]string{from.Base, to.Base}] {
+			return
+		}
+		linked[[2]string{from.Base, to.Base}] = true
+		links[from.Base] = append(links[from.Base], export.Link{
+			Label:  "→ " + to.View.Name,
+			Href:   to.Base + ".html",
+			Target: target,
+		})
+		links[to.Base] = append(links[to.Base], export.Link{
+			Label: "← " + from.View.Name,
+			Href:  from.Base + ".html",
+		})
+	}
+
+	index := viewIndex(views, state)
+	for _, l := range navigation(stories, state) {
+		from, ok := index[viewKey(l.From)]
+		to, ok2 := index[viewKey(l.To)]
+		if ok && ok2 {
+			link(from, to, l.To+" "+to.View.Name)
+		}
+	}
+	// Stories the chunker split into several views step through them in order
+	for i := 1; i < len(views); i++ {
+		if views[i].Story.ID == views[i-1].Story.ID {
+			link(views[i-1], views[i], views[i].View.Name)
+		}
+	}
+
+	for i, v := range views {
+		title := fmt.Sprintf("%s · %s", v.Story.ID, v.View.Name)
+		page := export.HTMLPage(pages[i], title, filepath.Base(prototypeIndex), links[v.Base])
+		if err := os.WriteFile(filepath.Join("output", v.Base+".html"), page, 0644); err != nil {
+			return fmt.Errorf("failed to write prototype page: %w", err)
+		}
+	}
+
+	var entries []export.IndexStory
+	for _, story := range stories {
+		entry := export.IndexStory{ID: story.ID, Title: story.Title, Narrative: story.Narrative}
+		for _, v := range views {
+			if v.Story.ID != story.ID {
+				continue
+			}
+			iv := export.IndexView{Name: v.View.Name, Href: v.Base + ".html"}
+			if _, err := os.Stat(filepath.Join("output", v.Base+".png")); err == nil {
+				iv.Image = v.Base + ".png"
+			}
+			entry.Views = append(entry.Views, iv)
+		}
+		entries = append(entries, entry)
+	}
+	if err := os.WriteFile(prototypeIndex, export.HTMLIndex("Holoplan prototype", entries), 0644); err != nil {
+		return fmt.Errorf("failed to write prototype index: %w", err)
+	}
+
+	fmt.Printf("🔗 Prototype written to %s (%d pages)\n", prototypeIndex, len(views))
+	return nil
+}
@@ -0,0 +1,152 @@
+// src/runner/nav.go
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"holoplan-cli/src/types"
+)
+
+// savedView is a view of the run whose layout was saved to output/.
+type savedView struct {
+	Story types.UserStory
+	View  types.ViewLayout
+	Index int    // position in the story's plan
+	Base  string // file name without extension
+	Path  string
+}
+
+// savedViews lists the run's saved layouts with the given extension, in the
+// order of the stories file and each story's view plan. Only views the
+// checkpoint records as saved count, so files left in output/ by an earlier
+// run are never picked up.
+func savedViews(stories []types.UserStory, state *RunState, ext string) ([]savedView, error) {
+	var views []savedView
+	for _, story := range stories {
+		plan := planOf(state, story.ID)
+		if plan == nil {
+			continue
+		}
+		for i, view := range plan.Views {
+			if vs, ok := state.Stories[story.ID].Views[view.Name]; !ok || !vs.Saved {
+				continue // not built (yet) in this run
+			}
+			base := sanitize(fmt.Sprintf("%s_%s", story.ID, view.Name))
+			path := filepath.Join("output", base+ext)
+			if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+				continue // not built (yet)
+			} else if err != nil {
+				return nil, err
+			}
+			views = append(views, savedView{Story: story, View: view, Index: i, Base: base, Path: path})
+		}
+	}
+	return views, nil
+}
+
+// planOf returns a story's checkpointed view plan, or nil.
+func planOf(state *RunState, storyID string) *types.ViewPlan {
+	if state == nil {
+		return nil
+	}
+	if st, ok := state.Stories[storyID]; ok {
+		return st.Plan
+	}
+	return nil
+}
+
+// viewKey matches view names across the stories file and the chunker's
+// plans: "plant_detail", "Plant Detail" and "PlantDetail" are the same view.
+func viewKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// storyViews returns the views a story declares (`views`, else `view`), or
+// failing that the views the chunker planned for it.
+func storyViews(story types.UserStory, plan *types.ViewPlan) []string {
+	if len(story.Views) > 0 {
+		return story.Views
+	}
+	if story.View != "" {
+		return []string{story.View}
+	}
+	var names []string
+	if plan != nil {
+		for _, v := range plan.Views {
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
+
+// navLink is one navigation step a story takes between two views, by name
+// as the story or plan gives it.
+type navLink struct {
+	From, To string
+	Story    types.UserStory
+}
+
+// navigation derives the view-to-view links of the stories: from a story's
+// interaction origin to its first view, through its views in order, and from
+// its last view to its resulting view. Stories sharing a step each get a link.
+func navigation(stories []types.UserStory, state *RunState) []navLink {
+	var links []navLink
+	add := func(from, to string, story types.UserStory) {
+		if f, t := viewKey(from), viewKey(to); f != "" && t != "" && f != t {
+			links = append(links, navLink{From: from, To: to, Story: story})
+		}
+	}
+
+	for _, story := range stories {
+		views := storyViews(story, planOf(state, story.ID))
+		if len(views) == 0 {
+			continue
+		}
+		add(story.InteractionOrigin, views[0], story)
+		for i := 1; i < len(views); i++ {
+			add(views[i-1], views[i], story)
+		}
+		add(views[len(views)-1], story.ResultingView, story)
+	}
+	return links
+}
+
+// viewIndex maps view keys to saved views. A saved view answers to its plan
+// name and to the name its story declares for it, so links written against
+// the stories file reach the chunker's views.
+func viewIndex(views []savedView, state *RunState) map[string]savedView {
+	index := make(map[string]savedView)
+	set := func(name string, v savedView) {
+		if k := viewKey(name); k != "" {
+			if _, taken := index[k]; !taken {
+				index[k] = v
+			}
+		}
+	}
+
+	for _, v := range views {
+		set(v.View.Name, v)
+	}
+	for _, v := range views {
+		declared := storyViews(v.Story, nil)
+		plan := planOf(state, v.Story.ID)
+		switch {
+		case plan != nil && len(declared) == len(plan.Views):
+			set(declared[v.Index], v)
+		case len(declared) > 0 && v.Index == 0:
+			set(declared[0], v)
+		}
+	}
+	return index
+}
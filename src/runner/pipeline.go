@@ -5,10 +5,15 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"slices"
 	"strings"
+	"syscall"
+	"time"
 
 	"holoplan-cli/src/agents"
+	"holoplan-cli/src/config"
 	"holoplan-cli/src/shared"
 	"holoplan-cli/src/types"
 	"holoplan-cli/src/validator"
@@ -19,82 +24,339 @@ import (
 
 const MaxCorrections = 1
 
-// RunPipeline now accepts a format ("drawio" or "figma")
-func RunPipeline(yamlPath string, format string) error {
-	stories, err := loadStories(yamlPath)
+// Options configures a single pipeline run.
+type Options struct {
+	StoriesPath string
+	Format      string // "drawio", "figma", or an export format built from Draw.io
+	Resume      bool   // continue from output/.holoplan_state.json
+	FixMode     string // FixBefore (default), FixInstead or FixOff
+	Config      config.Config
+}
+
+// RunPipeline chunks, builds, audits and validates every story in opts.StoriesPath.
+// Progress is checkpointed after each stage; Ctrl-C writes a final checkpoint
+// and merges the views saved so far.
+func RunPipeline(opts Options) error {
+	if opts.FixMode == "" {
+		opts.FixMode = FixBefore
+	}
+	if err := checkFixMode(opts.FixMode); err != nil {
+		return err
+	}
+	if err := checkFormat(opts.Format); err != nil {
+		return err
+	}
+
+	stories, err := loadStories(opts.StoriesPath)
 	if err != nil {
 		return fmt.Errorf("failed to load stories: %w", err)
 	}
 
+	state := newRunState(opts.StoriesPath, opts.Format)
+	if opts.Resume {
+		prev, err := loadRunState(stateFile)
+		if err != nil {
+			return fmt.Errorf("failed to load checkpoint: %w", err)
+		}
+		switch {
+		case prev == nil:
+			log.Printf("⚠️ No checkpoint found at %s — starting a fresh run", stateFile)
+		case prev.Format != opts.Format:
+			return fmt.Errorf("checkpoint was created with --format %s, not %s", prev.Format, opts.Format)
+		default:
+			if prev.StoriesPath != opts.StoriesPath {
+				log.Printf("⚠️ Checkpoint was created from %s — resuming with %s", prev.StoriesPath, opts.StoriesPath)
+			}
+			fmt.Printf("⏯️  Resuming from checkpoint %s\n", stateFile)
+			state = prev
+			state.StoriesPath = opts.StoriesPath
+			state.Completed = false
+		}
+	}
+
+	cp := &checkpoint{path: stateFile, state: state}
+	stop := handleInterrupt(cp, opts, stories)
+	defer stop()
+
 	for _, story := range stories {
 		fmt.Printf("🔍 Processing Story: %s\n", story.ID)
 
-		viewPlan, ok := safeChunk(story)
-		if !ok {
-			log.Printf("⚠️ Failed to chunk story: %s — skipping\n", story.ID)
-			continue
+		var viewPlan types.ViewPlan
+		if plan := cp.state.story(story.ID).Plan; plan != nil {
+			fmt.Printf("⏭️  Using checkpointed plan for story: %s\n", story.ID)
+			viewPlan = *plan
+		} else {
+			var ok bool
+			start := time.Now()
+			viewPlan, ok = safeChunk(story)
+			if !ok {
+				log.Printf("⚠️ Failed to chunk story: %s — skipping\n", story.ID)
+				continue
+			}
+			elapsed := time.Since(start)
+			cp.update(func(s *RunState) {
+				st := s.story(story.ID)
+				st.Plan = &viewPlan
+				st.ChunkTime = elapsed
+			})
 		}
 
 		for _, view := range viewPlan.Views {
-			fmt.Printf("⚙️  Generating view: %s\n", view.Name)
+			processView(cp, story, view, opts)
+		}
+	}
 
-			output, ok := safeBuild(view, story, format)
-			if !ok {
-				log.Printf("⚠️ Failed to build layout for view: %s\n", view.Name)
-				continue
-			}
+	// Every view has been through the pipeline, so the run is complete as far
+	// as the report written by the merge is concerned; a failed merge undoes it
+	cp.update(func(s *RunState) { s.Completed = true })
+	if err := cp.locked(func() error { return mergeOutput(opts, stories, cp.state) }); err != nil {
+		cp.update(func(s *RunState) { s.Completed = false })
+		return fmt.Errorf("failed to merge %s files: %w", opts.Format, err)
+	}
 
-			// Only audit and resolve for Draw.io (XML-based)
-			if format == "drawio" {
-				// Audit the initial XML using view.Narrative
-				critique, ok := safeAudit(view.Narrative, output)
-				if !ok {
-					log.Printf("⚠️ Failed to audit layout for view: %s\n", view.Name)
-					continue
-				}
-
-				// Attempt resolution only if there are issues and within MaxCorrections
-				if critique.HasIssues() {
-					fmt.Printf("🔁 Correction attempt 1 for %s\n", view.Name)
-					// Pass view.Narrative to Resolve
-					resolvedXML, ok := safeResolve(output, critique, view.Narrative)
-					if ok {
-						output = resolvedXML // Use resolved XML if successful
-					} else {
-						log.Printf("⚠️ Resolve failed at attempt 1 — proceeding with original XML\n")
-					}
-				} else {
-					log.Printf("✅ No issues found in initial audit for view: %s", view.Name)
-				}
-
-				// Skip re-auditing the corrected XML
-				output = shared.ForceQuoteAllAttributes(output)
-				if err := validator.CheckLayout(output); err != nil {
-					log.Printf("❌ Layout validation failed: %v", err)
-				} else {
-					fmt.Println("✅ Spatial layout passed")
-				}
-			} else {
-				// For Figma, no audit/resolver/validation yet
-				fmt.Println("✅ Figma layout generated (no audit/validation yet)")
-			}
+	fmt.Println("[✓] Pipeline completed successfully")
+	return nil
+}
+
+// processView runs the build → audit → resolve → validate → save stages for one
+// view, skipping any stage already recorded in the checkpoint.
+func processView(cp *checkpoint, story types.UserStory, view types.ViewLayout, opts Options) {
+	format := layoutFormat(opts.Format)
+
+	cp.mu.Lock()
+	vs := *cp.state.view(story.ID, view.Name)
+	cp.mu.Unlock()
+
+	if vs.Saved {
+		fmt.Printf("⏭️  Skipping completed view: %s\n", view.Name)
+		return
+	}
+
+	sanitized := vs.Sanitized
+	if vs.Output == "" {
+		fmt.Printf("⚙️  Generating view: %s\n", view.Name)
+
+		start := time.Now()
+		output, fixes, ok := safeBuild(view, story, format)
+		if !ok {
+			log.Printf("⚠️ Failed to build layout for view: %s\n", view.Name)
+			return
+		}
+		elapsed := time.Since(start)
+		vs.Output = output
+		sanitized = fixes
+		cp.update(func(s *RunState) {
+			v := s.view(story.ID, view.Name)
+			v.Output = output
+			v.Sanitized = fixes
+			v.timed("build", elapsed)
+		})
+	} else {
+		fmt.Printf("⏭️  Using checkpointed layout for view: %s\n", view.Name)
+	}
 
-			err = saveOutput(story.ID, view.Name, output, format)
-			if err != nil {
-				log.Printf("⚠️ Failed to save output: %v", err)
+	output := vs.Output
+
+	rules, err := opts.Config.Rules.ForView(view.Type)
+	if err != nil {
+		log.Printf("⚠️ %v — using project-wide rules", err)
+	}
+
+	// Mechanical fixes first, so the audit and resolver see a cleaner layout.
+	// fixed records whether output is the fixer's result, so it runs again
+	// only if the resolver replaces the layout, and once on what is saved.
+	var fixes []string
+	fixed := false
+	if format == "drawio" && opts.FixMode != FixOff && !vs.Resolved {
+		output, fixes = autoFix(output, rules)
+		fixed = true
+	}
+
+	// Audit the initial layout using view.Narrative
+	critique := vs.Critique
+	if critique == nil {
+		start := time.Now()
+		c, ok := safeAudit(view.Narrative, output, format)
+		if !ok {
+			log.Printf("⚠️ Failed to audit layout for view: %s\n", view.Name)
+			return
+		}
+		elapsed := time.Since(start)
+		critique = &c
+		cp.update(func(s *RunState) {
+			v := s.view(story.ID, view.Name)
+			v.Critique = &c
+			v.timed("audit", elapsed)
+		})
+	}
+
+	// Attempt resolution only if there are issues and within MaxCorrections
+	if critique.HasIssues() && opts.FixMode == FixInstead {
+		log.Printf("⏭️  Skipping LLM resolver for %s (--fix instead)", view.Name)
+	} else if critique.HasIssues() && !vs.Resolved {
+		fmt.Printf("🔁 Correction attempt 1 for %s\n", view.Name)
+		// Pass view.Narrative to Resolve
+		start := time.Now()
+		resolved, fixes, ok := safeResolve(output, *critique, view.Narrative, format)
+		elapsed := time.Since(start)
+		sanitized = append(slices.Clip(sanitized), fixes...)
+		var recheck *types.Critique
+		var recheckTime time.Duration
+		if ok {
+			output = resolved // Use the resolved layout if successful
+			fixed = false
+
+			// Audit once more for the report; the result does not feed back
+			start = time.Now()
+			if c, ok := safeAudit(view.Narrative, output, format); ok {
+				recheck = &c
+				recheckTime = time.Since(start)
 			}
+		} else {
+			log.Printf("⚠️ Resolve failed at attempt 1 — proceeding with original layout\n")
 		}
+		cp.update(func(s *RunState) {
+			v := s.view(story.ID, view.Name)
+			v.Output = output
+			v.Sanitized = sanitized
+			v.Resolved = true
+			v.Recheck = recheck
+			v.timed("resolve", elapsed)
+			if recheck != nil {
+				v.timed("recheck", recheckTime)
+			}
+		})
+	} else if !critique.HasIssues() {
+		log.Printf("✅ No issues found in initial audit for view: %s", view.Name)
 	}
 
-	// Only merge for Draw.io
+	start := time.Now()
+	var diags []validator.Diagnostic
 	if format == "drawio" {
-		if err := mergeDrawio("output/final.drawio"); err != nil {
-			return fmt.Errorf("failed to merge drawio files: %w", err)
+		var repairs, applied []string
+		if quoted := shared.ForceQuoteAllAttributes(output); quoted != output {
+			output = quoted
+			sanitized = append(slices.Clip(sanitized), "quoted unquoted attribute values")
+		}
+		output, repairs = repairStructure(output)
+		fixes = append(fixes, repairs...)
+		if opts.FixMode != FixOff && !fixed {
+			output, applied = autoFix(output, rules)
+			fixes = append(fixes, applied...)
 		}
+		diags = reportLayout(output, rules)
+	} else {
+		diags = reportFigma(output, rules)
+	}
+	cp.update(func(s *RunState) {
+		v := s.view(story.ID, view.Name)
+		v.Sanitized = sanitized
+		v.Fixes = fixes
+		v.Diagnostics = diags
+		v.timed("validate", time.Since(start))
+	})
+
+	err = cp.locked(func() error {
+		path, err := saveOutput(story.ID, view.Name, output, format)
+		if err == nil {
+			previewLog(path, output, opts.Config.Preview)
+		}
+		return err
+	})
+	if err != nil {
+		log.Printf("⚠️ Failed to save output: %v", err)
+		return
 	}
+	cp.update(func(s *RunState) { s.view(story.ID, view.Name).Saved = true })
+}
 
-	fmt.Println("[✓] Pipeline completed successfully")
-	return nil
+// repairStructure applies the validator's deterministic structural repairs
+// and returns the result with the repairs made, or the input unchanged if it
+// cannot be parsed.
+func repairStructure(xml string) (string, []string) {
+	repaired, fixes, err := validator.Repair(xml)
+	if err != nil {
+		log.Printf("⚠️ Structural repair skipped: %v", err)
+		return xml, nil
+	}
+	for _, fix := range fixes {
+		fmt.Printf("🔧 %s\n", fix)
+	}
+	return repaired, fixes
+}
+
+// reportLayout runs the spatial validator, prints every diagnostic found and
+// returns them.
+func reportLayout(xml string, rules validator.Rules) []validator.Diagnostic {
+	diags, err := validator.ValidateWith(xml, rules)
+	switch {
+	case err != nil:
+		log.Printf("❌ Layout validation failed: %v", err)
+	case len(diags) == 0:
+		fmt.Println("✅ Spatial layout passed")
+	default:
+		log.Printf("❌ Layout validation found %d error(s), %d warning(s)",
+			validator.Count(diags, validator.SeverityError),
+			validator.Count(diags, validator.SeverityWarning))
+		validator.RenderText(os.Stdout, diags)
+	}
+	return diags
+}
+
+// reportFigma validates Figma JSON against the builder's node rules and the
+// layout rules, prints every diagnostic found and returns them.
+func reportFigma(raw string, rules validator.Rules) []validator.Diagnostic {
+	diags, err := validator.ValidateFigma(raw, rules)
+	switch {
+	case err != nil:
+		log.Printf("❌ Figma validation failed: %v", err)
+	case len(diags) == 0:
+		fmt.Println("✅ Figma layout passed")
+	default:
+		log.Printf("❌ Figma validation found %d error(s), %d warning(s)",
+			validator.Count(diags, validator.SeverityError),
+			validator.Count(diags, validator.SeverityWarning))
+		validator.RenderText(os.Stdout, diags)
+	}
+	return diags
+}
+
+// handleInterrupt checkpoints the run and merges the views saved so far when
+// the user presses Ctrl-C. The returned func stops listening for signals.
+func handleInterrupt(cp *checkpoint, opts Options, stories []types.UserStory) func() {
+	sigs := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-sigs:
+		case <-done:
+			return
+		}
+		// A second Ctrl-C during the partial merge kills the process
+		signal.Stop(sigs)
+
+		fmt.Println("\n🛑 Interrupted — writing checkpoint")
+		cp.locked(func() error {
+			if err := cp.saveLocked(); err != nil {
+				log.Printf("⚠️ Failed to write checkpoint: %v", err)
+			}
+			if err := mergeOutput(opts, stories, cp.state); err != nil {
+				log.Printf("⚠️ Partial merge failed: %v", err)
+			} else {
+				fmt.Printf("🧩 Partial merge written to %s\n", mergedPath(opts.Format))
+			}
+			return nil
+		})
+		fmt.Println("⏯️  Run `holoplan run --resume` to continue")
+		os.Exit(130)
+	}()
+
+	return func() {
+		signal.Stop(sigs)
+		close(done)
+	}
 }
 
 func loadStories(path string) ([]types.UserStory, error) {
@@ -113,33 +375,38 @@ func safeChunk(story types.UserStory) (types.ViewPlan, bool) {
 }
 
 // Updated to accept format
-func safeBuild(view types.ViewLayout, story types.UserStory, format string) (string, bool) {
+func safeBuild(view types.ViewLayout, story types.UserStory, format string) (string, []string, bool) {
 	defer recoverLLM("Build")
 	output := agents.Build(view, story, format)
 
+	var fixes []string
 	if format == "drawio" {
 		// Ensure XML is quoted before validation
-		output = shared.ForceQuoteAllAttributes(output)
+		if quoted := shared.ForceQuoteAllAttributes(output); quoted != output {
+			output = quoted
+			fixes = append(fixes, "quoted unquoted attribute values")
+		}
 
 		// Validate XML syntax
 		doc := etree.NewDocument()
 		if err := doc.ReadFromString(output); err != nil {
 			log.Printf("⚠️ Invalid XML generated by Build: %v", err)
-			return "", false
+			return "", nil, false
 		}
 	}
 
-	return output, true
+	return output, fixes, true
 }
 
-func safeAudit(narrative string, xml string) (types.Critique, bool) {
+func safeAudit(narrative string, xml string, format string) (types.Critique, bool) {
 	defer recoverLLM("Audit")
-	return agents.Audit(narrative, xml), true
+	return agents.Audit(narrative, xml, format), true
 }
 
-func safeResolve(xml string, critique types.Critique, narrative string) (string, bool) {
+func safeResolve(xml string, critique types.Critique, narrative string, format string) (string, []string, bool) {
 	defer recoverLLM("Resolve")
-	return agents.Resolve(xml, critique, narrative), true
+	resolved, fixes := agents.Resolve(xml, critique, narrative, format)
+	return resolved, fixes, true
 }
 
 func recoverLLM(agent string) {
@@ -148,10 +415,10 @@ func recoverLLM(agent string) {
 	}
 }
 
-// Updated to save .json for Figma
-func saveOutput(storyID, viewName string, content string, format string) error {
+// saveOutput writes a view to output/ (.json for Figma) and returns its path.
+func saveOutput(storyID, viewName string, content string, format string) (string, error) {
 	if err := os.MkdirAll("output", os.ModePerm); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
+		return "", fmt.Errorf("failed to create output directory: %w", err)
 	}
 
 	// Combine storyID and viewName, then sanitize
@@ -165,10 +432,10 @@ func saveOutput(storyID, viewName string, content string, format string) error {
 	}
 
 	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+		return "", fmt.Errorf("failed to write file: %w", err)
 	}
 
-	return nil
+	return filename, nil
 }
 
 func sanitize(name string) string {
@@ -177,22 +444,80 @@ func sanitize(name string) string {
 	return strings.ToLower(name)
 }
 
-// mergeDrawio builds a valid <mxfile> with one <diagram> per input .drawio file.
-func mergeDrawio(outputPath string) error {
-	files, err := filepath.Glob("output/*.drawio")
+// checkFormat rejects unknown --format values.
+func checkFormat(format string) error {
+	switch format {
+	case "drawio", "figma", "html", "excalidraw", "salt", "bmml":
+		return nil
+	}
+	return fmt.Errorf("unknown format %q (want drawio, figma, html, excalidraw, salt or bmml)", format)
+}
+
+// layoutFormat is what the builder generates for an output format: Figma
+// JSON for figma, Draw.io XML for drawio and the formats exported from it.
+func layoutFormat(format string) string {
+	if format == "figma" {
+		return "figma"
+	}
+	return "drawio"
+}
+
+// mergedPath is where mergeOutput writes the combined document for a format.
+func mergedPath(format string) string {
+	if format == "figma" {
+		return "output/final.figma.json"
+	}
+	return "output/final.drawio"
+}
+
+// mergeOutput combines the saved views into a single multi-page document,
+// writes its previews (every view stacked in an SVG and tiled in a PNG
+// contact sheet) and, for export formats, exports the run.
+func mergeOutput(opts Options, stories []types.UserStory, state *RunState) error {
+	path := mergedPath(opts.Format)
+	var err error
+	if opts.Format == "figma" {
+		err = mergeFigma(path, stories, state)
+	} else {
+		err = mergeDrawio(path, stories, state)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to scan output files: %w", err)
+		return err
+	}
+
+	if merged, err := os.ReadFile(path); err == nil {
+		previewLog(path, string(merged), opts.Config.Preview)
+	}
+	if err := writeNavigation(navigationPath, stories, state); err != nil {
+		log.Printf("⚠️ %v", err)
+	}
+	if err := writeCanvas(canvasPath, opts.Format, stories, state); err != nil {
+		log.Printf("⚠️ %v", err)
 	}
+	if err := writeReport(opts.Format, stories, state); err != nil {
+		log.Printf("⚠️ %v", err)
+	}
+
+	return exportOutput(opts.Format, stories, state)
+}
 
-	if len(files) == 0 {
-		return fmt.Errorf("no .drawio files found in output directory")
+// mergeDrawio builds a valid <mxfile> with one <diagram> per saved view, in
+// the order of the stories file and each story's view plan.
+func mergeDrawio(outputPath string, stories []types.UserStory, state *RunState) error {
+	views, err := savedViews(stories, state, ".drawio")
+	if err != nil {
+		return fmt.Errorf("failed to scan output files: %w", err)
+	}
+	if len(views) == 0 {
+		return fmt.Errorf("no saved .drawio views found in output directory")
 	}
 
 	finalDoc := etree.NewDocument()
 	finalDoc.CreateProcInst("xml", `version="1.0" encoding="UTF-8"`)
 	mxfile := finalDoc.CreateElement("mxfile")
 
-	for _, file := range files {
+	for _, v := range views {
+		file := v.Path
 		content, err := os.ReadFile(file)
 		if err != nil {
 			return fmt.Errorf("failed to read %s: %w", file, err)
@@ -222,10 +547,7 @@ func mergeDrawio(outputPath string) error {
 		// Create a <diagram> element and append the <mxGraphModel> into it
 		diagram := mxfile.CreateElement("diagram")
 
-		// Clean up the filename for the diagram name
-		baseName := filepath.Base(file)
-		diagramName := strings.TrimSuffix(baseName, filepath.Ext(baseName))
-		diagram.CreateAttr("name", diagramName)
+		diagram.CreateAttr("name", v.Base)
 
 		// Add the model to the diagram
 		diagram.AddChild(model.Copy())
@@ -0,0 +1,56 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"holoplan-cli/src/types"
+
+	"github.com/beevik/etree"
+)
+
+func TestMergeDrawio(t *testing.T) {
+	t.Chdir(t.TempDir())
+	stories := []types.UserStory{{ID: "US-2"}, {ID: "US-1"}}
+	state := newRunState("stories.yaml", "drawio")
+	plan := func(id string, views ...string) {
+		p := &types.ViewPlan{StoryID: id}
+		for _, v := range views {
+			p.Views = append(p.Views, types.ViewLayout{Name: v})
+		}
+		state.story(id).Plan = p
+	}
+	plan("US-2", "Search", "Results")
+	plan("US-1", "Home", "Login")
+	state.view("US-2", "Search").Saved = true
+	state.view("US-2", "Results").Saved = true
+	state.view("US-1", "Home").Saved = true
+	state.view("US-1", "Login") // built but not saved when interrupted
+
+	if err := os.MkdirAll("output", 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, base := range []string{"us-2_search", "us-2_results", "us-1_home", "us-1_login", "us-0_stale", "final"} {
+		if err := os.WriteFile(filepath.Join("output", base+".drawio"), []byte(layout), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := filepath.Join("output", "final.drawio")
+	if err := mergeDrawio(path, stories, state); err != nil {
+		t.Fatalf("mergeDrawio: %v", err)
+	}
+	doc := etree.NewDocument()
+	if err := doc.ReadFromFile(path); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, d := range doc.FindElements("//diagram") {
+		names = append(names, d.SelectAttrValue("name", ""))
+	}
+	if want := []string{"us-2_search", "us-2_results", "us-1_home"}; !slices.Equal(names, want) {
+		t.Errorf("pages = %v, want %v", names, want)
+	}
+}
@@ -0,0 +1,104 @@
+// src/runner/preview.go
+package runner
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"holoplan-cli/src/config"
+	"holoplan-cli/src/export"
+	"holoplan-cli/src/ir"
+)
+
+// RunPreview writes SVG and PNG previews next to each .drawio or .figma.json
+// file: one page per view file, or for a merged document every page stacked
+// in the SVG and tiled as a contact sheet in the PNG.
+func RunPreview(paths []string, pv config.Preview) error {
+	failed := 0
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", path, err)
+			failed++
+			continue
+		}
+		outs, err := writePreview(path, string(data), pv)
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", path, err)
+			failed++
+			continue
+		}
+		fmt.Printf("🖼️  %s → %s\n", path, strings.Join(outs, ", "))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d file(s) could not be previewed", failed)
+	}
+	return nil
+}
+
+// writePreview renders the layout saved at path as an SVG and a PNG next to
+// it and returns their paths.
+func writePreview(path, content string, pv config.Preview) ([]string, error) {
+	doc, err := loadLayout(path, content)
+	if err != nil {
+		return nil, err
+	}
+
+	var svg, img []byte
+	if len(doc.Pages) == 1 {
+		svg = export.SVG(doc.Pages[0])
+		img, err = export.PNG(doc.Pages[0], pv.Scale)
+	} else {
+		svg = export.SVGDocument(doc)
+		img, err = export.ContactSheet(doc, pv.Scale, pv.Columns)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var outs []string
+	for _, f := range []struct {
+		ext  string
+		data []byte
+	}{{".svg", svg}, {".png", img}} {
+		out := previewPath(path, f.ext)
+		if err := os.WriteFile(out, f.data, 0644); err != nil {
+			return outs, fmt.Errorf("failed to write preview: %w", err)
+		}
+		outs = append(outs, out)
+	}
+	return outs, nil
+}
+
+// previewLog writes previews for a pipeline output, logging failures rather
+// than failing the run.
+func previewLog(path, content string, pv config.Preview) {
+	if outs, err := writePreview(path, content, pv); err != nil {
+		log.Printf("⚠️ Preview for %s skipped: %v", path, err)
+	} else {
+		fmt.Printf("🖼️  Preview written to %s\n", strings.Join(outs, ", "))
+	}
+}
+
+// loadLayout converts a saved layout to the IR, by file type.
+func loadLayout(path, content string) (*ir.Document, error) {
+	if strings.HasSuffix(strings.ToLower(path), ".json") {
+		return ir.FromFigma(content)
+	}
+	return ir.FromDrawio(content)
+}
+
+// previewPath swaps a layout file's extension (.drawio, .figma.json, .json)
+// for ext.
+func previewPath(path, ext string) string {
+	lower := strings.ToLower(path)
+	for _, suffix := range []string{".figma.json", ".json", ".drawio", ".xml"} {
+		if strings.HasSuffix(lower, suffix) {
+			return path[:len(path)-len(suffix)] + ext
+		}
+	}
+	return path + ext
+}
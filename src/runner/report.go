@@ -0,0 +1,386 @@
+// src/runner/report.go
+package runner
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"holoplan-cli/src/types"
+	"holoplan-cli/src/validator"
+)
+
+const (
+	reportMarkdown = "output/report.md"
+	reportHTML     = "output/report.html"
+)
+
+// stages are the timed stages of a view, in pipeline order.
+var stages = []string{"build", "audit", "resolve", "recheck", "validate"}
+
+// reportStory is what the run report says about one story.
+type reportStory struct {
+	Story     types.UserStory
+	Plan      *types.ViewPlan
+	ChunkTime time.Duration
+	Views     []reportView
+}
+
+// reportView is what the run report says about one planned view.
+type reportView struct {
+	View  types.ViewLayout
+	State ViewState
+	File  string // saved layout, relative to output/; empty until saved
+	Image string // PNG preview, relative to output/
+}
+
+// writeReport writes output/report.md and output/report.html, summarizing
+// every story of the run: the chunker's reasoning, the planned views and
+// their components, audit issues before and after the resolver, validator
+// diagnostics, the fixes applied, stage timings and preview images.
+func writeReport(format string, stories []types.UserStory, state *RunState) error {
+	ext := ".drawio"
+	if layoutFormat(format) == "figma" {
+		ext = ".figma.json"
+	}
+
+	var report []reportStory
+	for _, story := range stories {
+		rs := reportStory{Story: story}
+		st, ok := state.Stories[story.ID]
+		if ok && st.Plan != nil {
+			rs.Plan, rs.ChunkTime = st.Plan, st.ChunkTime
+			for _, view := range rs.Plan.Views {
+				rv := reportView{View: view}
+				if vs, ok := st.Views[view.Name]; ok {
+					rv.State = *vs
+				}
+				base := sanitize(fmt.Sprintf("%s_%s", story.ID, view.Name))
+				if rv.State.Saved && exists(filepath.Join("output", base+ext)) {
+					rv.File = base + ext
+				}
+				if exists(filepath.Join("output", base+".png")) {
+					rv.Image = base + ".png"
+				}
+				rs.Views = append(rs.Views, rv)
+			}
+		}
+		report = append(report, rs)
+	}
+
+	generated := time.Now().Format("2006-01-02 15:04")
+	if err := os.WriteFile(reportMarkdown, reportMD(report, state, generated), 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if err := os.WriteFile(reportHTML, reportPage(report, state, generated), 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	fmt.Printf("📋 Report written to %s and %s\n", reportMarkdown, reportHTML)
+	return nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// auditLists returns the audit issues of a view before and after the
+// resolver, each with a note for when there is no list to show.
+func (rv reportView) auditLists() (before []string, beforeNote string, after []string, afterNote string) {
+	vs := rv.State
+	switch {
+	case vs.Critique == nil:
+		return nil, "Not audited", nil, "Not audited"
+	case !vs.Critique.HasIssues():
+		return nil, "No issues", nil, "Resolver not needed"
+	}
+	before = vs.Critique.Issues
+	switch {
+	case vs.Recheck != nil && vs.Recheck.HasIssues():
+		after = vs.Recheck.Issues
+	case vs.Recheck != nil:
+		afterNote = "No issues"
+	case vs.Resolved:
+		afterNote = "Resolver failed or the resolved layout could not be audited"
+	default:
+		afterNote = "Resolver not run"
+	}
+	return before, "", after, afterNote
+}
+
+// timings returns a view's stage timings in pipeline order and their total.
+func (rv reportView) timings() ([]string, time.Duration) {
+	var parts []string
+	var total time.Duration
+	for _, stage := range stages {
+		if d, ok := rv.State.Timings[stage]; ok {
+			parts = append(parts, stage+" "+duration(d))
+			total += d
+		}
+	}
+	return parts, total
+}
+
+// summary returns a story's figures for the overview table.
+func (rs reportStory) summary() (saved, issuesBefore, issuesAfter, errs, warns int, total time.Duration) {
+	total = rs.ChunkTime
+	for _, rv := range rs.Views {
+		if rv.File != "" {
+			saved++
+		}
+		before, _, after, _ := rv.auditLists()
+		issuesBefore += len(before)
+		if rv.State.Recheck != nil {
+			issuesAfter += len(after)
+		} else {
+			issuesAfter += len(before)
+		}
+		errs += validator.Count(rv.State.Diagnostics, validator.SeverityError)
+		warns += validator.Count(rv.State.Diagnostics, validator.SeverityWarning)
+		_, t := rv.timings()
+		total += t
+	}
+	return
+}
+
+func duration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
+
+// reportMD renders the report as Markdown.
+func reportMD(report []reportStory, state *RunState, generated string) []byte {
+	var b bytes.Buffer
+	b.WriteString("# Holoplan run report\n\n")
+	fmt.Fprintf(&b, "Stories: `%s` · Format: `%s` · %s · Generated %s\n\n", state.StoriesPath, state.Format, runStatus(state), generated)
+
+	b.WriteString("| Story | Title | Views | Audit issues (before → after) | Errors | Warnings | Time |\n")
+	b.WriteString("|---|---|---|---|---|---|---|\n")
+	for _, rs := range report {
+		saved, before, after, errs, warns, total := rs.summary()
+		fmt.Fprintf(&b, "| [%s](#%s) | %s | %d/%d | %d → %d | %d | %d | %s |\n",
+			rs.Story.ID, anchor(rs.Story.ID), mdCell(rs.Story.Title), saved, len(rs.Views), before, after, errs, warns, duration(total))
+	}
+
+	for _, rs := range report {
+		fmt.Fprintf(&b, "\n<a id=\"%s\"></a>\n\n## %s · %s\n\n", anchor(rs.Story.ID), rs.Story.ID, rs.Story.Title)
+		if n := strings.TrimSpace(rs.Story.Narrative); n != "" {
+			b.WriteString("> " + strings.ReplaceAll(n, "\n", "  \n> ") + "\n\n")
+		}
+		if rs.Plan == nil {
+			b.WriteString("*Not chunked.*\n")
+			continue
+		}
+		if r := strings.TrimSpace(rs.Plan.Reasoning); r != "" {
+			fmt.Fprintf(&b, "**Chunker reasoning:** %s\n\n", r)
+		}
+		if rs.ChunkTime > 0 {
+			fmt.Fprintf(&b, "Chunked into %d view(s) in %s.\n", len(rs.Views), duration(rs.ChunkTime))
+		} else {
+			fmt.Fprintf(&b, "Chunked into %d view(s).\n", len(rs.Views))
+		}
+
+		for _, rv := range rs.Views {
+			fmt.Fprintf(&b, "\n### %s", rv.View.Name)
+			if rv.View.Type != "" {
+				fmt.Fprintf(&b, " (%s)", rv.View.Type)
+			}
+			b.WriteString("\n\n")
+			if rv.Image != "" {
+				fmt.Fprintf(&b, "![%s](%s)\n\n", rv.View.Name, rv.Image)
+			}
+			if n := strings.TrimSpace(rv.View.Narrative); n != "" {
+				fmt.Fprintf(&b, "%s\n\n", n)
+			}
+			if rv.File != "" {
+				fmt.Fprintf(&b, "- **Layout:** [%s](%s)\n", rv.File, rv.File)
+			} else {
+				b.WriteString("- **Layout:** not saved\n")
+			}
+			if len(rv.View.Components) > 0 {
+				fmt.Fprintf(&b, "- **Components:** %s\n", strings.Join(rv.View.Components, ", "))
+			}
+			before, beforeNote, after, afterNote := rv.auditLists()
+			mdList(&b, "Audit before resolve", before, beforeNote)
+			mdList(&b, "Audit after resolve", after, afterNote)
+			mdList(&b, "Sanitizer fixes", rv.State.Sanitized, "None")
+			mdList(&b, "Fixes applied", rv.State.Fixes, "None")
+			if rv.State.Saved {
+				fmt.Fprintf(&b, "- **Validator:** %d error(s), %d warning(s)\n",
+					validator.Count(rv.State.Diagnostics, validator.SeverityError),
+					validator.Count(rv.State.Diagnostics, validator.SeverityWarning))
+			}
+			if parts, total := rv.timings(); len(parts) > 0 {
+				fmt.Fprintf(&b, "- **Timings:** %s (total %s)\n", strings.Join(parts, " · "), duration(total))
+			}
+
+			if len(rv.State.Diagnostics) > 0 {
+				b.WriteString("\n| Severity | Rule | Message |\n|---|---|---|\n")
+				for _, d := range rv.State.Diagnostics {
+					fmt.Fprintf(&b, "| %s | %s | %s |\n", d.Severity, d.Rule, mdCell(d.Message))
+				}
+			}
+		}
+	}
+	return b.Bytes()
+}
+
+// mdList writes a labelled bullet with its items nested, or the note when
+// there are none.
+func mdList(b *bytes.Buffer, label string, items []string, note string) {
+	if len(items) == 0 {
+		fmt.Fprintf(b, "- **%s:** %s\n", label, note)
+		return
+	}
+	fmt.Fprintf(b, "- **%s:**\n", label)
+	for _, item := range items {
+		fmt.Fprintf(b, "  - %s\n", mdCell(strings.TrimSpace(item)))
+	}
+}
+
+// mdCell keeps text from breaking a Markdown table row or being read as
+// HTML.
+func mdCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ", "<", "&lt;").Replace(s)
+}
+
+// anchor is the link target of a story's section.
+func anchor(id string) string {
+	return "story-" + strings.ToLower(viewKey(id))
+}
+
+func runStatus(state *RunState) string {
+	if state.Completed {
+		return "Completed"
+	}
+	return "Partial run"
+}
+
+const reportStyle = `*{box-sizing:border-box}
+body{margin:0;font-family:Helvetica,Arial,sans-serif;background:#f0f0f0;color:#222;line-height:1.4}
+main{max-width:1100px;margin:0 auto;padding:24px}
+h1{margin:0 0 4px}
+.meta{color:#666;margin:0 0 16px}
+table{border-collapse:collapse;width:100%;background:#fff;font-size:14px}
+th,td{border:1px solid #ddd;padding:6px 8px;text-align:left;vertical-align:top}
+th{background:#f7f7f7}
+.story{background:#fff;margin:24px 0;padding:16px 20px;box-shadow:0 1px 4px rgba(0,0,0,.2)}
+.story h2{margin:0 0 8px}
+blockquote{margin:0 0 12px;padding:4px 12px;border-left:4px solid #ccc;color:#555;white-space:pre-line}
+.view{border-top:1px solid #eee;margin-top:16px;padding-top:12px}
+.view h3{margin:0 0 8px}
+.view img{display:block;max-width:100%;max-height:420px;border:1px solid #ccc;margin:8px 0}
+.view ul{margin:4px 0}
+.error{color:#b00020}
+.warning{color:#a15c00}
+.none{color:#888}
+`
+
+// reportPage renders the report as a standalone HTML page.
+func reportPage(report []reportStory, state *RunState, generated string) []byte {
+	esc := html.EscapeString
+	var b bytes.Buffer
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>Holoplan run report</title>\n<style>\n%s</style>\n</head>\n<body>\n<main>\n", reportStyle)
+	b.WriteString("<h1>Holoplan run report</h1>\n")
+	fmt.Fprintf(&b, `<p class="meta">Stories: <code>%s</code> · Format: <code>%s</code> · %s · Generated %s</p>`+"\n",
+		esc(state.StoriesPath), esc(state.Format), runStatus(state), generated)
+
+	b.WriteString("<table>\n<tr><th>Story</th><th>Title</th><th>Views</th><th>Audit issues (before → after)</th><th>Errors</th><th>Warnings</th><th>Time</th></tr>\n")
+	for _, rs := range report {
+		saved, before, after, errs, warns, total := rs.summary()
+		fmt.Fprintf(&b, `<tr><td><a href="#%s">%s</a></td><td>%s</td><td>%d/%d</td><td>%d → %d</td><td>%d</td><td>%d</td><td>%s</td></tr>`+"\n",
+			anchor(rs.Story.ID), esc(rs.Story.ID), esc(rs.Story.Title), saved, len(rs.Views), before, after, errs, warns, duration(total))
+	}
+	b.WriteString("</table>\n")
+
+	for _, rs := range report {
+		fmt.Fprintf(&b, `<section class="story" id="%s">`+"\n<h2>%s · %s</h2>\n", anchor(rs.Story.ID), esc(rs.Story.ID), esc(rs.Story.Title))
+		if n := strings.TrimSpace(rs.Story.Narrative); n != "" {
+			fmt.Fprintf(&b, "<blockquote>%s</blockquote>\n", esc(n))
+		}
+		if rs.Plan == nil {
+			b.WriteString("<p><em>Not chunked.</em></p>\n</section>\n")
+			continue
+		}
+		if r := strings.TrimSpace(rs.Plan.Reasoning); r != "" {
+			fmt.Fprintf(&b, "<p><strong>Chunker reasoning:</strong> %s</p>\n", esc(r))
+		}
+		fmt.Fprintf(&b, "<p>Chunked into %d view(s)", len(rs.Views))
+		if rs.ChunkTime > 0 {
+			fmt.Fprintf(&b, " in %s", duration(rs.ChunkTime))
+		}
+		b.WriteString(".</p>\n")
+
+		for _, rv := range rs.Views {
+			b.WriteString(`<div class="view">` + "\n<h3>" + esc(rv.View.Name))
+			if rv.View.Type != "" {
+				fmt.Fprintf(&b, " (%s)", esc(rv.View.Type))
+			}
+			b.WriteString("</h3>\n")
+			if rv.Image != "" {
+				fmt.Fprintf(&b, `<img src="%s" alt="%s">`+"\n", esc(rv.Image), esc(rv.View.Name))
+			}
+			if n := strings.TrimSpace(rv.View.Narrative); n != "" {
+				fmt.Fprintf(&b, "<p>%s</p>\n", esc(n))
+			}
+
+			b.WriteString("<ul>\n")
+			if rv.File != "" {
+				fmt.Fprintf(&b, `<li><strong>Layout:</strong> <a href="%s">%s</a></li>`+"\n", esc(rv.File), esc(rv.File))
+			} else {
+				b.WriteString(`<li><strong>Layout:</strong> <span class="none">not saved</span></li>` + "\n")
+			}
+			if len(rv.View.Components) > 0 {
+				fmt.Fprintf(&b, "<li><strong>Components:</strong> %s</li>\n", esc(strings.Join(rv.View.Components, ", ")))
+			}
+			before, beforeNote, after, afterNote := rv.auditLists()
+			htmlList(&b, "Audit before resolve", before, beforeNote)
+			htmlList(&b, "Audit after resolve", after, afterNote)
+			htmlList(&b, "Sanitizer fixes", rv.State.Sanitized, "None")
+			htmlList(&b, "Fixes applied", rv.State.Fixes, "None")
+			if rv.State.Saved {
+				fmt.Fprintf(&b, "<li><strong>Validator:</strong> %d error(s), %d warning(s)</li>\n",
+					validator.Count(rv.State.Diagnostics, validator.SeverityError),
+					validator.Count(rv.State.Diagnostics, validator.SeverityWarning))
+			}
+			if parts, total := rv.timings(); len(parts) > 0 {
+				fmt.Fprintf(&b, "<li><strong>Timings:</strong> %s (total %s)</li>\n", esc(strings.Join(parts, " · ")), duration(total))
+			}
+			b.WriteString("</ul>\n")
+
+			if len(rv.State.Diagnostics) > 0 {
+				b.WriteString("<table>\n<tr><th>Severity</th><th>Rule</th><th>Message</th></tr>\n")
+				for _, d := range rv.State.Diagnostics {
+					fmt.Fprintf(&b, `<tr><td class="%s">%s</td><td>%s</td><td>%s</td></tr>`+"\n",
+						esc(string(d.Severity)), esc(string(d.Severity)), esc(d.Rule), esc(d.Message))
+				}
+				b.WriteString("</table>\n")
+			}
+			b.WriteString("</div>\n")
+		}
+		b.WriteString("</section>\n")
+	}
+	b.WriteString("</main>\n</body>\n</html>\n")
+	return b.Bytes()
+}
+
+// htmlList writes a labelled list item with its items nested, or the note
+// when there are none.
+func htmlList(b *bytes.Buffer, label string, items []string, note string) {
+	if len(items) == 0 {
+		fmt.Fprintf(b, `<li><strong>%s:</strong> <span class="none">%s</span></li>`+"\n", label, html.EscapeString(note))
+		return
+	}
+	fmt.Fprintf(b, "<li><strong>%s:</strong><ul>", label)
+	for _, item := range items {
+		fmt.Fprintf(b, "<li>%s</li>", html.EscapeString(strings.TrimSpace(item)))
+	}
+	b.WriteString("</ul></li>\n")
+}
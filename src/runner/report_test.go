@@ -0,0 +1,97 @@
+package runner
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"holoplan-cli/src/types"
+	"holoplan-cli/src/validator"
+)
+
+func critique(issues ...string) *types.Critique {
+	return &types.Critique{Issues: issues}
+}
+
+func TestAuditLists(t *testing.T) {
+	tests := []struct {
+		name                  string
+		state                 ViewState
+		before                []string
+		beforeNote, afterNote string
+		after                 []string
+	}{
+		{name: "not audited", state: ViewState{}, beforeNote: "Not audited", afterNote: "Not audited"},
+		{name: "clean audit", state: ViewState{Critique: critique("no issues")}, beforeNote: "No issues", afterNote: "Resolver not needed"},
+		{
+			name:      "resolver not run",
+			state:     ViewState{Critique: critique("overlap")},
+			before:    []string{"overlap"},
+			afterNote: "Resolver not run",
+		},
+		{
+			name:      "resolved layout failed its recheck",
+			state:     ViewState{Critique: critique("overlap"), Resolved: true},
+			before:    []string{"overlap"},
+			afterNote: "Resolver failed or the resolved layout could not be audited",
+		},
+		{
+			name:      "recheck is clean",
+			state:     ViewState{Critique: critique("overlap", "tiny text"), Resolved: true, Recheck: critique("no issues")},
+			before:    []string{"overlap", "tiny text"},
+			afterNote: "No issues",
+		},
+		{
+			name:   "issues left after the resolver",
+			state:  ViewState{Critique: critique("overlap", "tiny text"), Resolved: true, Recheck: critique("tiny text")},
+			before: []string{"overlap", "tiny text"},
+			after:  []string{"tiny text"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, beforeNote, after, afterNote := reportView{State: tt.state}.auditLists()
+			if !slices.Equal(before, tt.before) || beforeNote != tt.beforeNote {
+				t.Errorf("before = %q, %q; want %q, %q", before, beforeNote, tt.before, tt.beforeNote)
+			}
+			if !slices.Equal(after, tt.after) || afterNote != tt.afterNote {
+				t.Errorf("after = %q, %q; want %q, %q", after, afterNote, tt.after, tt.afterNote)
+			}
+		})
+	}
+}
+
+func TestReportSummary(t *testing.T) {
+	warning := validator.Diagnostic{Rule: validator.RuleSpacing, Severity: validator.SeverityWarning}
+	failure := validator.Diagnostic{Rule: validator.RuleCollision, Severity: validator.SeverityError}
+	rs := reportStory{
+		ChunkTime: time.Second,
+		Views: []reportView{
+			{ // resolved, one issue left
+				File: "us-1_home.drawio",
+				State: ViewState{
+					Critique: critique("overlap", "tiny text"), Resolved: true, Recheck: critique("tiny text"),
+					Diagnostics: []validator.Diagnostic{warning, warning},
+					Timings:     map[string]time.Duration{"build": 2 * time.Second, "audit": time.Second},
+				},
+			},
+			{ // audited but never resolved, so its issues remain
+				File: "us-1_search.drawio",
+				State: ViewState{
+					Critique:    critique("missing label"),
+					Diagnostics: []validator.Diagnostic{failure},
+					Timings:     map[string]time.Duration{"validate": 500 * time.Millisecond},
+				},
+			},
+			{ // planned but not saved
+				State: ViewState{Critique: critique("no issues")},
+			},
+		},
+	}
+	saved, before, after, errs, warns, total := rs.summary()
+	got := []any{saved, before, after, errs, warns, total}
+	want := []any{2, 3, 2, 1, 2, 4500 * time.Millisecond}
+	if !slices.Equal(got, want) {
+		t.Errorf("summary = %v, want %v (saved, issues before, issues after, errors, warnings, time)", got, want)
+	}
+}
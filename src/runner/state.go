@@ -0,0 +1,152 @@
+// src/runner/state.go
+package runner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"holoplan-cli/src/types"
+	"holoplan-cli/src/validator"
+)
+
+const stateFile = "output/.holoplan_state.json"
+
+// RunState records which stages have completed for every story and view,
+// so an interrupted run can pick up exactly where it stopped.
+type RunState struct {
+	StoriesPath string                 `json:"stories_path"`
+	Format      string                 `json:"format"`
+	Completed   bool                   `json:"completed"`
+	Stories     map[string]*StoryState `json:"stories"`
+}
+
+// StoryState holds the chunked plan and per-view progress of one story.
+type StoryState struct {
+	Plan      *types.ViewPlan       `json:"plan,omitempty"`
+	ChunkTime time.Duration         `json:"chunk_time,omitempty"`
+	Views     map[string]*ViewState `json:"views,omitempty"`
+}
+
+// ViewState holds the output of each completed stage for one view, and what
+// the run report needs to know about them.
+type ViewState struct {
+	Output   string          `json:"output,omitempty"`   // built (or resolved) layout
+	Critique *types.Critique `json:"critique,omitempty"` // audit result, nil until audited
+	Resolved bool            `json:"resolved,omitempty"` // resolve stage finished
+	Recheck  *types.Critique `json:"recheck,omitempty"`  // audit of the resolved layout
+	Saved    bool            `json:"saved,omitempty"`    // written to output/
+
+	Sanitized   []string                 `json:"sanitized,omitempty"`   // sanitizer fixes to the built or resolved XML
+	Fixes       []string                 `json:"fixes,omitempty"`       // structural repairs and auto-fixes applied
+	Diagnostics []validator.Diagnostic   `json:"diagnostics,omitempty"` // validator findings on the saved layout
+	Timings     map[string]time.Duration `json:"timings,omitempty"`     // by stage
+}
+
+// checkpoint guards the run state and output directory so that the interrupt
+// handler never observes a half-written file.
+type checkpoint struct {
+	mu    sync.Mutex
+	path  string
+	state *RunState
+}
+
+func newRunState(storiesPath, format string) *RunState {
+	return &RunState{
+		StoriesPath: storiesPath,
+		Format:      format,
+		Stories:     make(map[string]*StoryState),
+	}
+}
+
+// loadRunState reads a previous state file. A missing file is not an error.
+func loadRunState(path string) (*RunState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state RunState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	if state.Stories == nil {
+		state.Stories = make(map[string]*StoryState)
+	}
+	return &state, nil
+}
+
+// story returns the state for a story, creating it on first use.
+func (s *RunState) story(id string) *StoryState {
+	st, ok := s.Stories[id]
+	if !ok {
+		st = &StoryState{}
+		s.Stories[id] = st
+	}
+	if st.Views == nil {
+		st.Views = make(map[string]*ViewState)
+	}
+	return st
+}
+
+// view returns the state for a view of a story, creating it on first use.
+func (s *RunState) view(storyID, viewName string) *ViewState {
+	st := s.story(storyID)
+	vs, ok := st.Views[viewName]
+	if !ok {
+		vs = &ViewState{}
+		st.Views[viewName] = vs
+	}
+	return vs
+}
+
+// timed records how long a stage of the view took.
+func (v *ViewState) timed(stage string, d time.Duration) {
+	if v.Timings == nil {
+		v.Timings = make(map[string]time.Duration)
+	}
+	v.Timings[stage] = d
+}
+
+// update applies fn to the state and persists the result.
+func (c *checkpoint) update(fn func(*RunState)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fn(c.state)
+	if err := c.saveLocked(); err != nil {
+		fmt.Printf("⚠️ Failed to write checkpoint: %v\n", err)
+	}
+}
+
+// locked runs fn while holding the checkpoint lock.
+func (c *checkpoint) locked(fn func() error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return fn()
+}
+
+// saveLocked writes the state atomically via a temp file and rename.
+func (c *checkpoint) saveLocked() error {
+	if err := os.MkdirAll(filepath.Dir(c.path), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(c.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	return os.Rename(tmp, c.path)
+}
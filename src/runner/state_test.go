@@ -0,0 +1,176 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"holoplan-cli/src/config"
+	"holoplan-cli/src/types"
+	"holoplan-cli/src/validator"
+)
+
+func TestLoadRunState(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string // file content; empty for no file
+		wantNil bool
+		wantErr string
+	}{
+		{name: "missing file starts a fresh run", wantNil: true},
+		{name: "invalid JSON is an error", data: `{"stories": [`, wantErr: "failed to parse state file"},
+		{name: "no stories yet", data: `{"stories_path": "stories.json", "format": "drawio"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "state.json")
+			if tt.data != "" {
+				if err := os.WriteFile(path, []byte(tt.data), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			state, err := loadRunState(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantNil {
+				if state != nil {
+					t.Fatalf("state = %+v, want nil", state)
+				}
+				return
+			}
+			if state.Stories == nil {
+				t.Fatal("Stories map not initialised")
+			}
+			state.view("US-1", "Home") // must not panic on a loaded state
+		})
+	}
+}
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "output", ".holoplan_state.json")
+	cp := &checkpoint{path: path, state: newRunState("stories.json", "drawio")}
+
+	cp.update(func(s *RunState) {
+		st := s.story("US-1")
+		st.Plan = &types.ViewPlan{StoryID: "US-1", Views: []types.ViewLayout{{Name: "Home", Type: "primary", Narrative: "lands"}}}
+		st.ChunkTime = 2 * time.Second
+	})
+	cp.update(func(s *RunState) {
+		v := s.view("US-1", "Home")
+		v.Output = `<mxGraphModel/>`
+		v.Sanitized = []string{"quoted 1 unquoted attribute value(s)"}
+		v.timed("build", 3*time.Second)
+	})
+	cp.update(func(s *RunState) {
+		v := s.view("US-1", "Home")
+		v.Critique = &types.Critique{Issues: []string{"button overlaps card"}}
+		v.Resolved = true
+		v.Recheck = &types.Critique{Issues: []string{"no issues"}}
+		v.Fixes = []string{`moved "Save" (btn) down to y=120`}
+		v.Diagnostics = []validator.Diagnostic{{
+			Rule: "overlap", Severity: validator.SeverityWarning,
+			CellIDs: []string{"a", "b"}, Labels: []string{"A", "B"},
+			Bounds:  []validator.Rect{{X: 0, Y: 0, Width: 10, Height: 10}, {X: 5, Y: 5, Width: 10, Height: 10}},
+			Message: "A overlaps B",
+		}}
+		v.Saved = true
+		v.timed("validate", time.Millisecond)
+	})
+
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temp file left behind: %v", err)
+	}
+	got, err := loadRunState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, cp.state) {
+		t.Errorf("reloaded state differs:\n got %+v\nwant %+v", got.Stories["US-1"].Views["Home"], cp.state.Stories["US-1"].Views["Home"])
+	}
+	if got := got.Stories["US-1"].Views["Home"].Timings; len(got) != 2 || got["build"] != 3*time.Second {
+		t.Errorf("timings = %v", got)
+	}
+}
+
+// layout is a Draw.io page with a single button, drawn off the page's top
+// left corner so the fixer has something to do.
+const layout = `<mxGraphModel><root><mxCell id="0"/><mxCell id="1" parent="0"/>` +
+	`<mxCell id="btn" value="Save" style="rounded=1;" vertex="1" parent="1"><mxGeometry x="-20" y="10" width="120" height="40" as="geometry"/></mxCell>` +
+	`</root></mxGraphModel>`
+
+func TestResume(t *testing.T) {
+	story := types.UserStory{ID: "US-1"}
+	view := types.ViewLayout{Name: "Home", Type: "primary", Narrative: "user saves"}
+
+	tests := []struct {
+		name      string
+		saved     ViewState // checkpointed before the run
+		wantFile  bool
+		wantFixes []string
+		stages    []string // timings recorded after the run
+	}{
+		{
+			name:   "a saved view is skipped",
+			saved:  ViewState{Output: layout, Critique: &types.Critique{Issues: []string{"no issues"}}, Saved: true},
+			stages: nil,
+		},
+		{
+			name:      "an audited view resumes at validation",
+			saved:     ViewState{Output: layout, Critique: &types.Critique{Issues: []string{"no issues"}}},
+			wantFile:  true,
+			wantFixes: []string{"shifted layout by (20, 0) out of negative coordinates"},
+			stages:    []string{"validate"},
+		},
+		{
+			name:     "a resolved view is fixed before saving",
+			saved:    ViewState{Output: layout, Critique: &types.Critique{Issues: []string{"overlap"}}, Resolved: true},
+			wantFile: true,
+			// Resolved layouts skip the pre-audit pass, so the final one runs.
+			wantFixes: []string{"shifted layout by (20, 0) out of negative coordinates"},
+			stages:    []string{"validate"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			cp := &checkpoint{path: stateFile, state: newRunState("stories.json", "drawio")}
+			vs := tt.saved
+			cp.state.story(story.ID).Views[view.Name] = &vs
+
+			processView(cp, story, view, Options{Format: "drawio", FixMode: FixBefore, Config: config.Default()})
+
+			_, err := os.Stat(filepath.Join("output", "us-1_home.drawio"))
+			if gotFile := err == nil; gotFile != tt.wantFile {
+				t.Fatalf("layout written = %v, want %v", gotFile, tt.wantFile)
+			}
+			got := cp.state.Stories[story.ID].Views[view.Name]
+			if tt.wantFile && !got.Saved {
+				t.Error("view not marked saved")
+			}
+			if !slices.Equal(got.Fixes, tt.wantFixes) {
+				t.Errorf("fixes = %q, want %q", got.Fixes, tt.wantFixes)
+			}
+			var stages []string
+			for stage := range got.Timings {
+				stages = append(stages, stage)
+			}
+			if !slices.Equal(stages, tt.stages) {
+				t.Errorf("stages run = %v, want %v", stages, tt.stages)
+			}
+			if !reflect.DeepEqual(got.Critique, tt.saved.Critique) {
+				t.Errorf("critique = %+v, want the checkpointed %+v", got.Critique, tt.saved.Critique)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// src/runner/validate.go
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"holoplan-cli/src/config"
+	"holoplan-cli/src/validator"
+)
+
+// fileReport groups the per-page results of one validated file for JSON output.
+type fileReport struct {
+	File  string                 `json:"file"`
+	Error string                 `json:"error,omitempty"`
+	Pages []validator.PageResult `json:"pages"`
+}
+
+// RunValidate lints existing .drawio files page by page, and Figma .json files
+// as a single page. output is "text" or "json"; viewType selects per-view-type
+// rule overrides from the config. With repair set, structural problems in
+// .drawio files are fixed in place before validating.
+func RunValidate(paths []string, output string, viewType string, repair bool, cfg config.Config) error {
+	if output != "text" && output != "json" {
+		return fmt.Errorf("unknown output format %q (want text or json)", output)
+	}
+
+	rules, err := cfg.Rules.ForView(viewType)
+	if err != nil {
+		return err
+	}
+
+	// Keep stdout clean for machine-readable output; progress goes to stderr
+	var progress io.Writer = os.Stdout
+	if output == "json" {
+		progress = os.Stderr
+	}
+
+	var reports []fileReport
+	failed := 0
+
+	for _, path := range paths {
+		report := fileReport{File: path, Pages: []validator.PageResult{}}
+
+		if repair && !strings.EqualFold(filepath.Ext(path), ".json") {
+			if err := repairFile(progress, path); err != nil {
+				fmt.Fprintf(progress, "⚠️ %s: repair failed: %v\n", path, err)
+			}
+		}
+
+		results, err := validator.CheckFile(path, rules)
+		if err != nil {
+			report.Error = err.Error()
+			reports = append(reports, report)
+			failed++
+			if output == "text" {
+				fmt.Printf("❌ %s: %v\n", path, err)
+			}
+			continue
+		}
+
+		for _, r := range results {
+			if validator.HasErrors(r.Diagnostics) {
+				failed++
+			}
+			if output == "text" {
+				printPageResult(path, r)
+			}
+		}
+		report.Pages = results
+		reports = append(reports, report)
+	}
+
+	if output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(reports); err != nil {
+			return fmt.Errorf("failed to encode JSON report: %w", err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d page(s) failed validation", failed)
+	}
+	return nil
+}
+
+func printPageResult(path string, r validator.PageResult) {
+	if len(r.Diagnostics) == 0 {
+		fmt.Printf("✅ %s [%s]: layout passed\n", path, r.Page)
+	} else {
+		fmt.Printf("📄 %s [%s]: %d error(s), %d warning(s)\n", path, r.Page,
+			validator.Count(r.Diagnostics, validator.SeverityError),
+			validator.Count(r.Diagnostics, validator.SeverityWarning))
+		validator.RenderText(os.Stdout, r.Diagnostics)
+	}
+}
+
+// repairFile rewrites a .drawio file with its structural problems fixed,
+// listing each fix on w.
+func repairFile(w io.Writer, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	repaired, pages, err := validator.RepairDocument(string(data))
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for _, page := range pages {
+		for _, fix := range page.Fixes {
+			fmt.Fprintf(w, "🔧 %s [%s]: %s\n", path, page.Page, fix)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return os.WriteFile(path, []byte(repaired), 0644)
+}
@@ -0,0 +1,115 @@
+package runner
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"holoplan-cli/src/config"
+)
+
+// cells wraps mxCell markup in a bare mxGraphModel.
+func cells(markup string) string {
+	return `<mxGraphModel><root><mxCell id="0"/><mxCell id="1" parent="0"/>` + markup + `</root></mxGraphModel>`
+}
+
+const (
+	button  = `<mxCell id="a" value="Save" vertex="1" parent="1"><mxGeometry x="10" y="10" width="100" height="44" as="geometry"/></mxCell>`
+	overlap = `<mxCell id="b" value="Cancel" vertex="1" parent="1"><mxGeometry x="50" y="20" width="100" height="44" as="geometry"/></mxCell>`
+)
+
+func TestRunValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  map[string]string // name → content; "missing" is not written
+		output string
+		want   string // error substring; empty if every page passes
+	}{
+		{
+			name:  "clean layout passes",
+			files: map[string]string{"ok.drawio": cells(button)},
+		},
+		{
+			name:  "collision fails the page",
+			files: map[string]string{"bad.drawio": cells(button + overlap)},
+			want:  "1 page(s) failed validation",
+		},
+		{
+			name: "every page of a multi-page file is checked",
+			files: map[string]string{"multi.drawio": `<mxfile><diagram name="Home">` + cells(button+overlap) +
+				`</diagram><diagram name="Login">` + cells(button) + `</diagram></mxfile>`},
+			want: "1 page(s) failed validation",
+		},
+		{
+			name:  "unreadable files count as failed",
+			files: map[string]string{"ok.drawio": cells(button), "missing": ""},
+			want:  "1 page(s) failed validation",
+		},
+		{
+			name:   "unknown output format",
+			files:  map[string]string{"ok.drawio": cells(button)},
+			output: "yaml",
+			want:   `unknown output format "yaml"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			var paths []string
+			for name, content := range tt.files {
+				path := filepath.Join(dir, name)
+				paths = append(paths, path)
+				if name == "missing" {
+					continue
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			output := tt.output
+			if output == "" {
+				output = "text"
+			}
+			err := RunValidate(paths, output, "", false, config.Default())
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("RunValidate: %v", err)
+			case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepairFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dup.drawio")
+	dup := strings.Replace(overlap, `id="b"`, `id="a"`, 1)
+	dup = strings.Replace(dup, `x="50" y="20"`, `x="10" y="100"`, 1)
+	if err := os.WriteFile(path, []byte(cells(button+dup)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var progress bytes.Buffer
+	if err := repairFile(&progress, path); err != nil {
+		t.Fatalf("repairFile: %v", err)
+	}
+	if !strings.Contains(progress.String(), `renamed duplicate id "a"`) {
+		t.Errorf("progress = %q, want the rename listed", progress.String())
+	}
+	if err := RunValidate([]string{path}, "text", "", false, config.Default()); err != nil {
+		t.Errorf("repaired file still fails: %v", err)
+	}
+
+	// A second repair finds nothing and leaves the file alone
+	before, _ := os.ReadFile(path)
+	progress.Reset()
+	if err := repairFile(&progress, path); err != nil {
+		t.Fatalf("repairFile: %v", err)
+	}
+	after, _ := os.ReadFile(path)
+	if progress.Len() != 0 || !bytes.Equal(before, after) {
+		t.Errorf("second repair changed the file: %q", progress.String())
+	}
+}
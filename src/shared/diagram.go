@@ -0,0 +1,37 @@
+// src/shared/diagram.go
+package shared
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// InflateDiagram decodes a compressed Draw.io <diagram> payload (base64 + raw
+// deflate + URL encoding, the diagrams.net default).
+func InflateDiagram(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", fmt.Errorf("diagram is empty")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to base64-decode diagram: %w", err)
+	}
+
+	inflated, err := io.ReadAll(flate.NewReader(bytes.NewReader(data)))
+	if err != nil {
+		return "", fmt.Errorf("failed to inflate diagram: %w", err)
+	}
+
+	decoded, err := url.PathUnescape(string(inflated))
+	if err != nil {
+		return "", fmt.Errorf("failed to URL-decode diagram: %w", err)
+	}
+	return decoded, nil
+}
@@ -10,23 +10,92 @@ import (
 	"github.com/beevik/etree"
 )
 
+// Bounds is an element's geometry in its parent's coordinate space.
+type Bounds struct {
+	X, Y, Width, Height float64
+}
+
+// Move records one geometry change made by ResolveOverlapsReport.
+type Move struct {
+	ID     string
+	Label  string
+	From   Bounds
+	To     Bounds
+	Reason string // "shifted right", "pushed down", "moved with row", "grew to fit children"
+}
+
+func (m Move) String() string {
+	name := m.ID
+	if m.Label != "" {
+		name = fmt.Sprintf("%q (%s)", m.Label, m.ID)
+	}
+	if m.Reason == reasonGrew {
+		return fmt.Sprintf("%s %s: %gx%g → %gx%g", name, m.Reason,
+			m.From.Width, m.From.Height, m.To.Width, m.To.Height)
+	}
+	return fmt.Sprintf("%s %s: (%g, %g) → (%g, %g)", name, m.Reason,
+		m.From.X, m.From.Y, m.To.X, m.To.Y)
+}
+
+const (
+	reasonRight = "shifted right"
+	reasonDown  = "pushed down"
+	reasonRow   = "moved with row"
+	reasonGrew  = "grew to fit children"
+)
+
+// rowTolerance is how close two tops must be for boxes to count as one row.
+const rowTolerance = 5.0
+
 type box struct {
-	Cell        *etree.Element
-	X, Y        int
-	Width       int
-	Height      int
-	Bottom, Top int
-	Right, Left int
+	Cell   *etree.Element
+	Geom   *etree.Element
+	ID     string
+	Label  string
+	Parent string
+	X, Y   float64
+	Width  float64
+	Height float64
+	Row    int // index of the row the box started in
 }
 
-// ResolveOverlaps detects and fixes 2D bounding box overlaps by adjusting y-values
+func (b box) right() float64  { return b.X + b.Width }
+func (b box) bottom() float64 { return b.Y + b.Height }
+
+func (b box) bounds() Bounds {
+	return Bounds{X: b.X, Y: b.Y, Width: b.Width, Height: b.Height}
+}
+
+// ResolveOverlaps detects and fixes 2D bounding box overlaps.
 func ResolveOverlaps(xml string, margin int) (string, error) {
+	out, _, err := ResolveOverlapsReport(xml, margin)
+	return out, err
+}
+
+// ResolveOverlapsReport fixes overlaps between sibling elements and reports
+// every change. Siblings are compared in their parent's (relative) coordinate
+// space. An overlapping element is first shifted right if it still fits in its
+// container (or the page), otherwise pushed down together with the rest of its
+// row, unless it overlaps a box of its own row, in which case it alone moves
+// down. Containers grow to keep their children inside. Nested groups are solved
+// before their parents so container growth is taken into account.
+func ResolveOverlapsReport(xml string, margin int) (string, []Move, error) {
 	doc := etree.NewDocument()
 	if err := doc.ReadFromString(xml); err != nil {
-		return "", fmt.Errorf("failed to parse XML: %w", err)
+		return "", nil, fmt.Errorf("failed to parse XML: %w", err)
 	}
 
-	var boxes []box
+	var pageWidth float64
+	if model := doc.FindElement("//mxGraphModel"); model != nil {
+		pageWidth = atof(model.SelectAttrValue("pageWidth", "0"))
+	}
+
+	// all holds every box in document order; boxes looks containers up by
+	// ID, the first cell winning when an ID is duplicated
+	var all []*box
+	boxes := make(map[string]*box)
+	groups := make(map[string][]*box)
+	var order []string // parent IDs in first-seen order, for determinism
 
 	for _, cell := range doc.FindElements("//mxCell") {
 		if cell.SelectAttrValue("vertex", "") != "1" {
@@ -37,66 +106,244 @@ func ResolveOverlaps(xml string, margin int) (string, error) {
 			continue
 		}
 
-		x := atoi(geom.SelectAttrValue("x", "0"))
-		y := atoi(geom.SelectAttrValue("y", "0"))
-		w := atoi(geom.SelectAttrValue("width", "0"))
-		h := atoi(geom.SelectAttrValue("height", "0"))
+		// <object>/<UserObject> wrappers carry the ID and label
+		id, label := cell.SelectAttrValue("id", ""), cell.SelectAttrValue("value", "")
+		if w := cell.Parent(); w != nil && (w.Tag == "object" || w.Tag == "UserObject") {
+			id, label = w.SelectAttrValue("id", id), w.SelectAttrValue("label", label)
+		}
 
-		boxes = append(boxes, box{
+		b := &box{
 			Cell:   cell,
-			X:      x,
-			Y:      y,
-			Width:  w,
-			Height: h,
-			Left:   x,
-			Right:  x + w,
-			Top:    y,
-			Bottom: y + h,
-		})
-	}
-
-	// Sort by top Y value
-	sort.SliceStable(boxes, func(i, j int) bool {
-		return boxes[i].Top < boxes[j].Top
+			Geom:   geom,
+			ID:     id,
+			Label:  label,
+			Parent: cell.SelectAttrValue("parent", ""),
+			X:      atof(geom.SelectAttrValue("x", "0")),
+			Y:      atof(geom.SelectAttrValue("y", "0")),
+			Width:  atof(geom.SelectAttrValue("width", "0")),
+			Height: atof(geom.SelectAttrValue("height", "0")),
+		}
+		all = append(all, b)
+		if _, dup := boxes[b.ID]; !dup {
+			boxes[b.ID] = b
+		}
+		if _, ok := groups[b.Parent]; !ok {
+			order = append(order, b.Parent)
+		}
+		groups[b.Parent] = append(groups[b.Parent], b)
+	}
+
+	// Deepest groups first, so a container's final size is known before its
+	// own siblings are arranged
+	depth := func(id string) int {
+		d := 0
+		seen := map[string]bool{}
+		for b, ok := boxes[id]; ok && !seen[b.ID]; b, ok = boxes[b.Parent] {
+			seen[b.ID] = true
+			d++
+		}
+		return d
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return depth(order[i]) > depth(order[j])
 	})
 
-	for i := 0; i < len(boxes); i++ {
-		curr := &boxes[i]
-		for j := 0; j < i; j++ {
-			prev := &boxes[j]
-			if isOverlapping(*prev, *curr) {
-				// Adjust Y to be below previous
-				newY := prev.Bottom + margin
-				geom := curr.Cell.FindElement("mxGeometry")
-				geom.RemoveAttr("y")
-				geom.CreateAttr("y", strconv.Itoa(newY))
-
-				// Update box geometry
-				curr.Top = newY
-				curr.Bottom = newY + curr.Height
+	var moves []Move
+	m := float64(margin)
+
+	for _, parentID := range order {
+		siblings := groups[parentID]
+		container, nested := boxes[parentID]
+
+		// Horizontal room: the container's width, else the page width, else
+		// the current extent of the layout
+		room := pageWidth
+		if nested {
+			room = container.Width
+		}
+		if room <= 0 {
+			for _, b := range siblings {
+				room = max(room, b.right())
 			}
 		}
+
+		moves = append(moves, arrangeGroup(siblings, m, room)...)
+
+		if nested {
+			if mv, ok := growToFit(container, siblings, m); ok {
+				moves = append(moves, mv)
+			}
+		}
+	}
+
+	for _, b := range all {
+		setAttr(b.Geom, "x", b.X)
+		setAttr(b.Geom, "y", b.Y)
+		setAttr(b.Geom, "width", b.Width)
+		setAttr(b.Geom, "height", b.Height)
 	}
 
 	out, err := doc.WriteToString()
 	if err != nil {
-		return "", fmt.Errorf("failed to serialize XML: %w", err)
+		return "", nil, fmt.Errorf("failed to serialize XML: %w", err)
 	}
-	return stripXMLDecl(out), nil
+	return stripXMLDecl(out), moves, nil
 }
 
-// Overlap test with bounding boxes
-func isOverlapping(a, b box) bool {
-	xOverlap := a.Left < b.Right && b.Left < a.Right
-	yOverlap := a.Top < b.Bottom && b.Top < a.Bottom
-	return xOverlap && yOverlap
+// arrangeGroup resolves overlaps among siblings in place.
+func arrangeGroup(siblings []*box, margin, room float64) []Move {
+	sort.SliceStable(siblings, func(i, j int) bool {
+		if siblings[i].Y != siblings[j].Y {
+			return siblings[i].Y < siblings[j].Y
+		}
+		return siblings[i].X < siblings[j].X
+	})
+
+	// Assign rows from the original tops
+	row := -1
+	var rowTop float64
+	for _, b := range siblings {
+		if row < 0 || b.Y-rowTop > rowTolerance {
+			row++
+			rowTop = b.Y
+		}
+		b.Row = row
+	}
+
+	var moves []Move
+	for i, curr := range siblings {
+		placed := siblings[:i]
+		if len(overlapping(curr, curr.X, curr.Y, placed)) == 0 {
+			continue
+		}
+
+		from := curr.bounds()
+
+		// Prefer sliding right along the row while there is room
+		if x, ok := slideRight(curr, placed, margin, room); ok {
+			curr.X = x
+			moves = append(moves, newMove(curr, from, reasonRight))
+			continue
+		}
+
+		// Otherwise push down below everything it hits. If that includes a
+		// box of its own row, moving the row cannot help, so it leaves the
+		// row; otherwise the whole row moves with it, earlier mates included
+		var mates, others []*box
+		for _, p := range placed {
+			if p.Row == curr.Row {
+				mates = append(mates, p)
+			} else {
+				others = append(others, p)
+			}
+		}
+		split := len(overlapping(curr, curr.X, curr.Y, mates)) > 0
+		group := append([]*box{curr}, mates...)
+		if split {
+			group, others = []*box{curr}, placed
+			curr.Row = -1 - i // a row of its own
+		}
+
+		dy := 0.0
+		for moved := true; moved; {
+			moved = false
+			for _, b := range group {
+				for _, h := range overlapping(b, b.X, b.Y+dy, others) {
+					if need := h.bottom() + margin - b.Y; need > dy {
+						dy, moved = need, true
+					}
+				}
+			}
+		}
+
+		curr.Y += dy
+		moves = append(moves, newMove(curr, from, reasonDown))
+		if split {
+			continue
+		}
+		for _, mate := range append(mates, siblings[i+1:]...) {
+			if mate.Row == curr.Row {
+				mateFrom := mate.bounds()
+				mate.Y += dy
+				moves = append(moves, newMove(mate, mateFrom, reasonRow))
+			}
+		}
+	}
+	return moves
+}
+
+// slideRight finds the smallest x ≥ b.X at which b clears every placed box
+// and still fits within room.
+func slideRight(b *box, placed []*box, margin, room float64) (float64, bool) {
+	x := b.X
+	for {
+		hits := overlapping(b, x, b.Y, placed)
+		if len(hits) == 0 {
+			return x, x != b.X
+		}
+		for _, h := range hits {
+			x = max(x, h.right()+margin)
+		}
+		if x+b.Width > room {
+			return 0, false
+		}
+	}
+}
+
+// growToFit enlarges a container so every child, plus margin, lies inside it.
+func growToFit(container *box, children []*box, margin float64) (Move, bool) {
+	from := container.bounds()
+	for _, c := range children {
+		if c.right() > container.Width {
+			container.Width = c.right() + margin
+		}
+		if c.bottom() > container.Height {
+			container.Height = c.bottom() + margin
+		}
+	}
+	if container.bounds() == from {
+		return Move{}, false
+	}
+	return newMove(container, from, reasonGrew), true
+}
+
+// overlapping returns the placed boxes that b would overlap at (x, y).
+func overlapping(b *box, x, y float64, placed []*box) []*box {
+	var hits []*box
+	for _, p := range placed {
+		if x < p.right() && p.X < x+b.Width && y < p.bottom() && p.Y < y+b.Height {
+			hits = append(hits, p)
+		}
+	}
+	return hits
+}
+
+func newMove(b *box, from Bounds, reason string) Move {
+	return Move{
+		ID:     b.ID,
+		Label:  b.Label,
+		From:   from,
+		To:     b.bounds(),
+		Reason: reason,
+	}
 }
 
 // --- Helpers ---
 
-func atoi(s string) int {
-	i, _ := strconv.Atoi(s)
-	return i
+func atof(s string) float64 {
+	f, _ := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return f
+}
+
+// setAttr writes a numeric attribute only when its value actually changed.
+func setAttr(el *etree.Element, key string, value float64) {
+	if el.SelectAttr(key) == nil && value == 0 {
+		return
+	}
+	if atof(el.SelectAttrValue(key, "0")) == value {
+		return
+	}
+	el.CreateAttr(key, strconv.FormatFloat(value, 'f', -1, 64))
 }
 
 func stripXMLDecl(xml string) string {
@@ -0,0 +1,160 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/beevik/etree"
+)
+
+// model wraps vertex cells, given as "id:parent:x,y,w,h", in an mxGraphModel
+// of the given page width (0 for none).
+func model(pageWidth float64, cells ...string) string {
+	var b strings.Builder
+	if pageWidth > 0 {
+		fmt.Fprintf(&b, `<mxGraphModel pageWidth="%g"><root>`, pageWidth)
+	} else {
+		b.WriteString(`<mxGraphModel><root>`)
+	}
+	b.WriteString(`<mxCell id="0"/><mxCell id="1" parent="0"/>`)
+	for _, c := range cells {
+		var id, parent string
+		var x, y, w, h float64
+		parts := strings.SplitN(c, ":", 3)
+		id, parent = parts[0], parts[1]
+		fmt.Sscanf(parts[2], "%g,%g,%g,%g", &x, &y, &w, &h)
+		fmt.Fprintf(&b, `<mxCell id="%s" value="%s" vertex="1" parent="%s"><mxGeometry x="%g" y="%g" width="%g" height="%g" as="geometry"/></mxCell>`,
+			id, id, parent, x, y, w, h)
+	}
+	b.WriteString(`</root></mxGraphModel>`)
+	return b.String()
+}
+
+// geometry reads back every vertex's box as "x,y,w,h".
+func geometry(t *testing.T, xml string) map[string]string {
+	t.Helper()
+	doc := etree.NewDocument()
+	if err := doc.ReadFromString(xml); err != nil {
+		t.Fatalf("output does not parse: %v", err)
+	}
+	out := make(map[string]string)
+	for _, cell := range doc.FindElements("//mxCell[@vertex='1']") {
+		g := cell.SelectElement("mxGeometry")
+		out[cell.SelectAttrValue("id", "")] = fmt.Sprintf("%s,%s,%s,%s",
+			g.SelectAttrValue("x", "0"), g.SelectAttrValue("y", "0"),
+			g.SelectAttrValue("width", "0"), g.SelectAttrValue("height", "0"))
+	}
+	return out
+}
+
+func TestResolveOverlapsReport(t *testing.T) {
+	tests := []struct {
+		name    string
+		xml     string
+		want    map[string]string
+		reasons []string // reason of each move, in order
+	}{
+		{
+			name:    "no overlap",
+			xml:     model(0, "a:1:0,0,100,50", "b:1:0,100,100,50"),
+			want:    map[string]string{"a": "0,0,100,50", "b": "0,100,100,50"},
+			reasons: nil,
+		},
+		{
+			name:    "slides right when there is room",
+			xml:     model(400, "a:1:0,0,100,50", "b:1:50,20,100,50"),
+			want:    map[string]string{"a": "0,0,100,50", "b": "110,20,100,50"},
+			reasons: []string{reasonRight},
+		},
+		{
+			name:    "pushed down when the page is full",
+			xml:     model(150, "a:1:0,0,100,50", "b:1:50,20,100,50"),
+			want:    map[string]string{"a": "0,0,100,50", "b": "50,60,100,50"},
+			reasons: []string{reasonDown},
+		},
+		{
+			// m1 comes first in its row and clears the header; m2 hits the
+			// header, so the row moves down as a whole and stays aligned
+			name: "earlier row mates move with the row",
+			xml:  model(500, "header:1:0,0,300,50", "m1:1:400,40,100,30", "m2:1:0,42,100,30"),
+			want: map[string]string{
+				"header": "0,0,300,50",
+				"m1":     "400,58,100,30",
+				"m2":     "0,60,100,30",
+			},
+			reasons: []string{reasonDown, reasonRow},
+		},
+		{
+			name: "later row mates move with the row",
+			xml:  model(300, "header:1:0,0,300,50", "l:1:0,40,100,30", "r:1:200,40,100,30"),
+			want: map[string]string{
+				"header": "0,0,300,50",
+				"l":      "0,60,100,30",
+				"r":      "200,60,100,30",
+			},
+			reasons: []string{reasonDown, reasonRow},
+		},
+		{
+			name:    "leaves its row when it overlaps a row mate",
+			xml:     model(150, "a:1:0,0,100,50", "b:1:50,2,100,50", "c:1:120,0,30,20"),
+			want:    map[string]string{"a": "0,0,100,50", "b": "50,60,100,50", "c": "120,0,30,20"},
+			reasons: []string{reasonDown},
+		},
+		{
+			name: "children are solved in their container, which grows",
+			xml:  model(0, "card:1:0,0,200,100", "x:card:10,10,150,50", "y:card:20,20,150,50"),
+			want: map[string]string{
+				"card": "0,0,200,130",
+				"x":    "10,10,150,50",
+				"y":    "20,70,150,50",
+			},
+			reasons: []string{reasonDown, reasonGrew},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, moves, err := ResolveOverlapsReport(tt.xml, 10)
+			if err != nil {
+				t.Fatalf("ResolveOverlapsReport: %v", err)
+			}
+			got := geometry(t, out)
+			for id, want := range tt.want {
+				if got[id] != want {
+					t.Errorf("%s = %s, want %s", id, got[id], want)
+				}
+			}
+			var reasons []string
+			for _, m := range moves {
+				reasons = append(reasons, m.Reason)
+			}
+			if fmt.Sprint(reasons) != fmt.Sprint(tt.reasons) {
+				t.Errorf("moves = %v, want reasons %v", moves, tt.reasons)
+			}
+		})
+	}
+}
+
+func TestResolveOverlapsDuplicateIDs(t *testing.T) {
+	// Both cells with ID "a" are written back, not just the last one
+	xml := model(150, "x:1:0,0,100,50", "a:1:50,20,100,50", "a:1:0,200,100,50")
+	out, moves, err := ResolveOverlapsReport(xml, 10)
+	if err != nil {
+		t.Fatalf("ResolveOverlapsReport: %v", err)
+	}
+	doc := etree.NewDocument()
+	if err := doc.ReadFromString(out); err != nil {
+		t.Fatalf("output does not parse: %v", err)
+	}
+	var got []string
+	for _, g := range doc.FindElements("//mxCell[@id='a']/mxGeometry") {
+		got = append(got, g.SelectAttrValue("x", "")+","+g.SelectAttrValue("y", ""))
+	}
+	if want := []string{"50,60", "0,200"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("a cells at %v, want %v", got, want)
+	}
+	if len(moves) != 1 || moves[0].To.Y != 60 {
+		t.Errorf("moves = %+v, want a single move to y=60", moves)
+	}
+}
@@ -38,8 +38,9 @@ func ExtractXMLFrom(response string) string {
 }
 
 // fixUnquotedAttributes ensures all attribute values are quoted, e.g., width=180 -> width="180"
-// But preserves the internal structure of style attributes which contain key=value pairs
-func fixUnquotedAttributes(xml string) string {
+// But preserves the internal structure of style attributes which contain key=value pairs.
+// It returns the number of values quoted.
+func fixUnquotedAttributes(xml string) (string, int) {
 	// fmt.Println("🛠️ Fixing unquoted XML attributes")
 
 	// Step 1: Extract and temporarily replace style attributes to protect them
@@ -73,7 +74,7 @@ func fixUnquotedAttributes(xml string) string {
 		xml = strings.Replace(xml, placeholder, style, 1)
 	}
 
-	return xml
+	return xml, count
 }
 
 // escapeInvalidEntities replaces standalone & with &amp;, excluding valid XML entities
@@ -93,18 +94,41 @@ func escapeInvalidEntities(xml string) string {
 
 // SanitizeXML ensures all <mxGeometry> elements have required attributes.
 // Also wraps unquoted attribute values and escapes invalid ampersands.
+// Each fix made is printed.
 func SanitizeXML(raw string) (string, error) {
-	raw = ForceQuoteAllAttributes(raw)
-	// fmt.Println("🧼 [SanitizeXML] ForceQuoteAllAttributes applied")
-	raw = fixUnquotedAttributes(raw)
-	raw = fixHalfQuotedAttributes(raw)
-	raw = escapeInvalidEntities(raw)
+	sanitized, fixes, err := SanitizeXMLFixes(raw)
+	for _, fix := range fixes {
+		fmt.Printf("🧪 %s\n", fix)
+	}
+	return sanitized, err
+}
+
+// SanitizeXMLFixes is SanitizeXML without the printing: it returns the
+// sanitized XML and a description of each fix made, for callers that record
+// them or must keep their output clean.
+func SanitizeXMLFixes(raw string) (string, []string, error) {
+	var fixes []string
+	if quoted := ForceQuoteAllAttributes(raw); quoted != raw {
+		raw = quoted
+		fixes = append(fixes, "quoted unquoted attribute values")
+	}
+	raw, unquoted := fixUnquotedAttributes(raw)
+	if unquoted > 0 {
+		fixes = append(fixes, fmt.Sprintf("quoted %d more unquoted attribute value(s)", unquoted))
+	}
+	raw, halfQuoted := fixHalfQuotedAttributes(raw)
+	if halfQuoted > 0 {
+		fixes = append(fixes, fmt.Sprintf("closed %d half-quoted attribute value(s)", halfQuoted))
+	}
+	escaped := escapeInvalidEntities(raw)
+	if n := strings.Count(escaped, "&amp;") - strings.Count(raw, "&amp;"); n > 0 {
+		fixes = append(fixes, fmt.Sprintf("escaped %d ampersand(s)", n))
+	}
+	raw = escaped
 
 	doc := etree.NewDocument()
-	// fmt.Println("📤 Sanitized XML preview:")
-	// fmt.Println(raw)
 	if err := doc.ReadFromString(raw); err != nil {
-		return "", fmt.Errorf("❌ etree failed to parse input XML: %w", err)
+		return "", fixes, fmt.Errorf("❌ etree failed to parse input XML: %w", err)
 	}
 
 	// Define required attributes and their defaults
@@ -118,15 +142,24 @@ func SanitizeXML(raw string) (string, error) {
 	}
 
 	for _, geo := range doc.FindElements("//mxGeometry") {
+		// Edge geometries are relative and positioned by their endpoints
+		if geo.SelectAttrValue("relative", "") == "1" {
+			continue
+		}
 		for _, key := range required {
 			if geo.SelectAttr(key) == nil {
 				geo.CreateAttr(key, defaults[key])
-				fmt.Printf("🧪 Filled missing '%s' with default '%s'\n", key, defaults[key])
+				id := "?"
+				if cell := geo.Parent(); cell != nil {
+					id = cell.SelectAttrValue("id", id)
+				}
+				fixes = append(fixes, fmt.Sprintf("filled missing '%s' of cell %s with default '%s'", key, id, defaults[key]))
 			}
 		}
 	}
 
-	return doc.WriteToString()
+	sanitized, err := doc.WriteToString()
+	return sanitized, fixes, err
 }
 
 // OffsetCellIDs modifies all mxCell id and parent attributes to avoid collisions.
@@ -186,8 +219,9 @@ func ForceQuoteAllAttributes(xml string) string {
 	return xml
 }
 
-// fixHalfQuotedAttributes detects values starting with a quote but missing the end quote
-func fixHalfQuotedAttributes(xml string) string {
+// fixHalfQuotedAttributes detects values starting with a quote but missing the end quote.
+// It returns the number of values closed.
+func fixHalfQuotedAttributes(xml string) (string, int) {
 	re := regexp.MustCompile(`\b([a-zA-Z_:]+)="([^"]*?)(\s+[a-zA-Z_:]+=)`)
 	count := 0
 
@@ -197,8 +231,5 @@ func fixHalfQuotedAttributes(xml string) string {
 		return regexp.MustCompile(`="([^"]*?)(\s+[a-zA-Z_:]+=)`).ReplaceAllString(match, `="$1"$2`)
 	})
 
-	if count > 0 {
-		fmt.Printf("🩹 Fixed %d half-quoted attribute(s)\n", count)
-	}
-	return xml
+	return xml, count
 }
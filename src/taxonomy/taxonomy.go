@@ -0,0 +1,191 @@
+// src/taxonomy/taxonomy.go
+package taxonomy
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Kind is the semantic role of a UI component, inferred from its label and style.
+type Kind string
+
+const (
+	Unknown Kind = ""
+
+	// Landmarks with conventional positions on the page
+	Navbar     Kind = "navbar"
+	Header     Kind = "header"
+	Footer     Kind = "footer"
+	Sidebar    Kind = "sidebar"
+	Breadcrumb Kind = "breadcrumb"
+	Modal      Kind = "modal"
+	FAB        Kind = "fab"
+
+	// Widgets
+	Button   Kind = "button"
+	Search   Kind = "search"
+	Checkbox Kind = "checkbox"
+	Radio    Kind = "radio"
+	Dropdown Kind = "dropdown"
+	Input    Kind = "input"
+	Tabs     Kind = "tabs"
+	Table    Kind = "table"
+	List     Kind = "list"
+	Card     Kind = "card"
+	Image    Kind = "image"
+	Link     Kind = "link"
+	Form     Kind = "form"
+	Text     Kind = "text"
+)
+
+// Landmark reports whether k is a page landmark with a conventional position
+// rather than a widget laid out in the content flow.
+func (k Kind) Landmark() bool {
+	switch k {
+	case Navbar, Header, Footer, Sidebar, Breadcrumb, Modal, FAB:
+		return true
+	}
+	return false
+}
+
+// Interactive reports whether k is a control the user taps or types into,
+// which therefore needs a usable touch target.
+func (k Kind) Interactive() bool {
+	switch k {
+	case Button, Search, Checkbox, Radio, Dropdown, Input, Tabs, Link, FAB:
+		return true
+	}
+	return false
+}
+
+// keywords are matched against whole words of the label, in this order, so
+// "Sidebar Navigation" is a sidebar and "Modal Footer" is a modal.
+var keywords = []struct {
+	kind   Kind
+	phrase []string
+}{
+	{FAB, []string{"fab", "floating action", "floating button", "floating action button"}},
+	{Breadcrumb, []string{"breadcrumb", "breadcrumbs", "breadcrumb trail"}},
+	{Sidebar, []string{"sidebar", "side bar", "side nav", "side navigation", "side menu", "sidenav", "drawer"}},
+	{Modal, []string{"modal", "dialog", "popup", "pop up", "lightbox", "overlay"}},
+	{Footer, []string{"footer", "foot", "page footer", "site footer"}},
+	{Header, []string{"header", "masthead", "banner", "page header"}},
+	{Navbar, []string{"nav", "navbar", "navigation", "menu bar", "menubar", "top bar", "topbar", "app bar", "appbar"}},
+	{Button, []string{"button", "buttons", "btn", "cta", "submit"}},
+	{Search, []string{"search", "search bar", "search box", "searchbar"}},
+	{Checkbox, []string{"checkbox", "checkboxes", "check box", "toggle", "switch"}},
+	{Radio, []string{"radio", "radio button", "radio buttons", "radio group"}},
+	{Dropdown, []string{"dropdown", "drop down", "select", "picker", "combo box", "combobox"}},
+	{Input, []string{"input", "inputs", "field", "fields", "text box", "textbox", "textarea", "text area"}},
+	{Tabs, []string{"tab", "tabs", "tab bar"}},
+	{Table, []string{"table", "grid", "data grid", "datagrid", "spreadsheet"}},
+	{List, []string{"list", "lists", "items", "feed"}},
+	{Card, []string{"card", "cards", "tile", "tiles"}},
+	{Image, []string{"image", "images", "photo", "photos", "picture", "pictures", "avatar", "thumbnail", "gallery", "logo"}},
+	{Link, []string{"link", "links", "hyperlink"}},
+	{Form, []string{"form", "forms"}},
+	{Text, []string{"label", "title", "heading", "text", "description", "paragraph", "caption", "subtitle"}},
+}
+
+// styleHints map Draw.io style fragments (shapes from the mockup libraries and
+// built-in cell types) to kinds.
+var styleHints = []struct {
+	fragment string
+	kind     Kind
+}{
+	{"mxgraph.mockup.navigation.breadcrumb", Breadcrumb},
+	{"mxgraph.mockup.buttons", Button},
+	{"mxgraph.mockup.forms.checkbox", Checkbox},
+	{"mxgraph.mockup.forms.radio", Radio},
+	{"mxgraph.mockup.forms.combobox", Dropdown},
+	{"mxgraph.mockup.forms.searchbox", Search},
+	{"mxgraph.mockup.forms.text", Input},
+	{"mxgraph.mockup.containers.tabs", Tabs},
+	{"mxgraph.mockup.containers.list", List},
+	{"mxgraph.mockup.graphics.", Image},
+	{"shape=image", Image},
+	{"mxgraph.mockup.containers.window", Modal},
+	{"mxgraph.android.fab", FAB},
+}
+
+// Classify infers a component's kind. An explicit `kind=<kind>` entry in the
+// style wins, then the label keywords, then the Draw.io shape in the style.
+func Classify(label, style string) Kind {
+	styles := ParseStyle(style)
+	if k, ok := styles["kind"]; ok && k != "" {
+		return Kind(strings.ToLower(k))
+	}
+
+	if kind := classifyLabel(label); kind != Unknown {
+		return kind
+	}
+
+	lowered := strings.ToLower(style)
+	for _, hint := range styleHints {
+		if strings.Contains(lowered, hint.fragment) {
+			return hint.kind
+		}
+	}
+
+	// A lone "+" in a round shape is the classic floating action button
+	if strings.TrimSpace(label) == "+" && strings.Contains(lowered, "ellipse") {
+		return FAB
+	}
+	if strings.HasPrefix(lowered, "text;") {
+		return Text
+	}
+	return Unknown
+}
+
+// classifyLabel matches label words against the keyword table.
+func classifyLabel(label string) Kind {
+	words := strings.FieldsFunc(strings.ToLower(stripTags(label)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return Unknown
+	}
+	padded := " " + strings.Join(words, " ") + " "
+
+	for _, entry := range keywords {
+		for _, phrase := range entry.phrase {
+			if strings.Contains(padded, " "+phrase+" ") {
+				return entry.kind
+			}
+		}
+	}
+	return Unknown
+}
+
+// ParseStyle splits a Draw.io style string ("rounded=1;fillColor=#fff") into
+// key/value pairs. Bare entries such as "ellipse" map to an empty value.
+func ParseStyle(style string) map[string]string {
+	out := make(map[string]string)
+	for _, part := range strings.Split(style, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(part, "=")
+		out[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return out
+}
+
+// stripTags drops HTML markup that diagrams.net stores in labels with html=1.
+func stripTags(s string) string {
+	var b strings.Builder
+	inTag := false
+	for _, r := range s {
+		switch {
+		case r == '<':
+			inTag = true
+			b.WriteRune(' ')
+		case r == '>':
+			inTag = false
+		case !inTag:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
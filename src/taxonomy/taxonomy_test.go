@@ -0,0 +1,51 @@
+package taxonomy
+
+import "testing"
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		label, style string
+		want         Kind
+	}{
+		{"Main Navigation", "rounded=0;", Navbar},
+		{"Sidebar Navigation", "", Sidebar},
+		{"Modal Footer", "", Modal},
+		{"Site footer © 2025", "", Footer},
+		{"<b>Page</b> header", "html=1;", Header},
+		{"Home › Plants › Ficus", "mxgraph.mockup.navigation.breadcrumb;", Breadcrumb},
+		{"Submit order", "", Button},
+		{"Email field", "", Input},
+		{"Gallery", "", Image},
+		{"+", "ellipse;fillColor=#f00;", FAB},
+		{"", "shape=mxgraph.mockup.forms.checkbox;", Checkbox},
+		{"Welcome back", "text;html=1;", Text},
+		{"Settings", "kind=sidebar;", Sidebar},
+		{"Navbar", "kind=card;", Card}, // an explicit kind wins over the label
+		{"navigation-bar", "", Navbar},
+		{"Snowfall", "", Unknown}, // no whole-word match for "fall" or "snow"
+		{"", "", Unknown},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.label, tt.style); got != tt.want {
+			t.Errorf("Classify(%q, %q) = %q, want %q", tt.label, tt.style, got, tt.want)
+		}
+	}
+}
+
+func TestKindGroups(t *testing.T) {
+	for _, k := range []Kind{Navbar, Header, Footer, Sidebar, Breadcrumb, Modal, FAB} {
+		if !k.Landmark() {
+			t.Errorf("%s is not a landmark", k)
+		}
+	}
+	for _, k := range []Kind{Button, Input, Link, FAB} {
+		if !k.Interactive() {
+			t.Errorf("%s is not interactive", k)
+		}
+	}
+	for _, k := range []Kind{Card, Text, Image, Unknown} {
+		if k.Landmark() || k.Interactive() {
+			t.Errorf("%q is a landmark or interactive", k)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+// src/validator/alignment.go
+package validator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"holoplan-cli/src/taxonomy"
+)
+
+// alignEpsilon absorbs floating point noise when comparing coordinates.
+const alignEpsilon = 0.5
+
+// siblingGroups groups cells by parent, in first-seen order, so alignment is
+// judged among elements that share a container.
+func siblingGroups(cells []mxCell) [][]mxCell {
+	index := make(map[string]int)
+	var groups [][]mxCell
+	for _, c := range cells {
+		i, ok := index[c.Parent]
+		if !ok {
+			i = len(groups)
+			index[c.Parent] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], c)
+	}
+	return groups
+}
+
+// leftColumns groups cells whose left edges lie within tolerance of the
+// column's first member, like the vertical-flow rule's X bands.
+func leftColumns(cells []mxCell, tolerance float64) [][]mxCell {
+	var columns [][]mxCell
+	for _, c := range cells {
+		placed := false
+		for i := range columns {
+			if abs(c.Geometry.X-columns[i][0].Geometry.X) <= tolerance {
+				columns[i] = append(columns[i], c)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			columns = append(columns, []mxCell{c})
+		}
+	}
+	return columns
+}
+
+// ──────────────────────────────────────────────
+// 📏 RULE: Left-Edge Alignment
+// ──────────────────────────────────────────────
+
+// checkAlignment flags elements a few pixels off their column's left edge.
+// The column's edge is its most common X (the leftmost on a tie).
+func checkAlignment(cells []mxCell, rule AlignmentRule) []Diagnostic {
+	var diags []Diagnostic
+	for _, group := range siblingGroups(cells) {
+		for _, col := range leftColumns(group, rule.ColumnTolerance) {
+			if len(col) < 2 {
+				continue
+			}
+
+			counts := make(map[float64]int)
+			for _, c := range col {
+				counts[c.Geometry.X]++
+			}
+			edge, best := 0.0, 0
+			for x, n := range counts {
+				if n > best || (n == best && x < edge) {
+					edge, best = x, n
+				}
+			}
+
+			for _, c := range col {
+				if abs(c.Geometry.X-edge) <= alignEpsilon {
+					continue
+				}
+				d := newDiagnostic(RuleAlignment, rule.Severity,
+					fmt.Sprintf("%s is misaligned by %gpx from its column's left edge at x=%g",
+						describe(c), c.Geometry.X-edge, edge), c)
+				d.Suggestion = &Rect{X: edge, Y: c.Geometry.Y, Width: c.Geometry.Width, Height: c.Geometry.Height}
+				diags = append(diags, d)
+			}
+		}
+	}
+	return diags
+}
+
+// ──────────────────────────────────────────────
+// ↔️ RULE: Consistent Spacing
+// ──────────────────────────────────────────────
+
+// checkSpacing compares the vertical gaps between stacked components in each
+// column against the column's median gap, rounded to the grid when one is
+// given so the two rules agree. Landmarks are left out, since the space around
+// a navbar or footer is rarely meant to match the content's.
+func checkSpacing(cells []mxCell, rule SpacingRule, grid float64) []Diagnostic {
+	var diags []Diagnostic
+	for _, group := range siblingGroups(cells) {
+		var content []mxCell
+		for _, c := range group {
+			if !taxonomy.Classify(c.Value, c.Style).Landmark() {
+				content = append(content, c)
+			}
+		}
+
+		for _, col := range leftColumns(content, rule.ColumnTolerance) {
+			sort.SliceStable(col, func(i, j int) bool {
+				return col[i].Geometry.Y < col[j].Geometry.Y
+			})
+
+			// Overlapping pairs are the collision rule's concern
+			type pair struct {
+				upper, lower mxCell
+				gap          float64
+			}
+			var pairs []pair
+			for i := 1; i < len(col); i++ {
+				gap := col[i].Geometry.Y - (col[i-1].Geometry.Y + col[i-1].Geometry.Height)
+				if gap >= 0 {
+					pairs = append(pairs, pair{col[i-1], col[i], gap})
+				}
+			}
+			if len(pairs) < 2 {
+				continue
+			}
+
+			gaps := make([]float64, len(pairs))
+			for i, p := range pairs {
+				gaps[i] = p.gap
+			}
+			sort.Float64s(gaps)
+			expected := gaps[(len(gaps)-1)/2]
+			if grid > 0 {
+				expected = math.Round(expected/grid) * grid
+			}
+
+			for _, p := range pairs {
+				if abs(p.gap-expected) <= rule.Tolerance {
+					continue
+				}
+				d := newDiagnostic(RuleSpacing, rule.Severity,
+					fmt.Sprintf("gap above %s is %gpx, expected %gpx like the rest of its column",
+						describe(p.lower), p.gap, expected), p.lower, p.upper)
+				g := p.lower.Geometry
+				d.Suggestion = &Rect{
+					X:      g.X,
+					Y:      p.upper.Geometry.Y + p.upper.Geometry.Height + expected,
+					Width:  g.Width,
+					Height: g.Height,
+				}
+				diags = append(diags, d)
+			}
+		}
+	}
+	return diags
+}
+
+// ──────────────────────────────────────────────
+// #️⃣ RULE: Grid Snapping
+// ──────────────────────────────────────────────
+
+// checkGrid flags elements whose position or size is off the layout grid.
+// Sizes round up so text that fits keeps fitting, and with every edge on the
+// grid the gaps between elements land on it too.
+func checkGrid(cells []mxCell, rule GridRule) []Diagnostic {
+	if rule.Size <= 0 {
+		return nil
+	}
+
+	snap := func(f float64) float64 { return math.Round(f/rule.Size) * rule.Size }
+	grow := func(f float64) float64 { return max(rule.Size, math.Ceil(f/rule.Size-1e-9)*rule.Size) }
+
+	var diags []Diagnostic
+	for _, c := range cells {
+		g := c.Geometry
+		if g.Width <= 0 || g.Height <= 0 {
+			continue // the size rule covers collapsed elements
+		}
+		s := Rect{X: snap(g.X), Y: snap(g.Y), Width: grow(g.Width), Height: grow(g.Height)}
+		if abs(g.X-s.X) <= alignEpsilon && abs(g.Y-s.Y) <= alignEpsilon &&
+			abs(g.Width-s.Width) <= alignEpsilon && abs(g.Height-s.Height) <= alignEpsilon {
+			continue
+		}
+		d := newDiagnostic(RuleGrid, rule.Severity,
+			fmt.Sprintf("%s at (%g, %g) %gx%g is off the %gpx grid", describe(c), g.X, g.Y, g.Width, g.Height, rule.Size), c)
+		d.Suggestion = &s
+		diags = append(diags, d)
+	}
+	return diags
+}
+
+// ──────────────────────────────────────────────
+// ⬌ RULE: Full-Width Bars
+// ──────────────────────────────────────────────
+
+// checkFullWidth requires top-level navbars and footers to span the layout,
+// from the leftmost to the rightmost edge of the page's top-level elements.
+func checkFullWidth(cv *canvas, rule FullWidthRule) []Diagnostic {
+	var top []mxCell
+	for _, c := range cv.cells {
+		if cv.byID[c.Parent].Vertex != "1" {
+			top = append(top, c)
+		}
+	}
+	if len(top) < 2 {
+		return nil
+	}
+
+	minX, maxX := top[0].Geometry.X, top[0].Geometry.X+top[0].Geometry.Width
+	for _, c := range top[1:] {
+		minX = min(minX, c.Geometry.X)
+		maxX = max(maxX, c.Geometry.X+c.Geometry.Width)
+	}
+
+	var diags []Diagnostic
+	for _, c := range top {
+		kind := taxonomy.Classify(c.Value, c.Style)
+		if kind != taxonomy.Navbar && kind != taxonomy.Footer {
+			continue
+		}
+		g := c.Geometry
+		if g.X-minX <= rule.Tolerance && maxX-(g.X+g.Width) <= rule.Tolerance {
+			continue
+		}
+		d := newDiagnostic(RuleFullWidth, rule.Severity,
+			fmt.Sprintf("%s %s should span the full layout width (%g to %g)", kind, describe(c), minX, maxX), c)
+		d.Suggestion = &Rect{X: minX, Y: g.Y, Width: maxX - minX, Height: g.Height}
+		diags = append(diags, d)
+	}
+	return diags
+}
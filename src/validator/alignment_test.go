@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAlignmentRules(t *testing.T) {
+	defaults := DefaultRules()
+	grid := defaults.Grid
+	grid.Enabled = true
+
+	tests := []struct {
+		name  string
+		xml   string
+		rules Rules
+		want  []string // "cell IDs → suggestion" of each diagnostic
+	}{
+		{
+			name:  "element off its column's most common left edge",
+			xml:   page("", "Name:10,0,200,40", "Email:10,60,200,40", "Phone:13,120,200,40"),
+			rules: Rules{Alignment: defaults.Alignment},
+			want:  []string{"[Phone] → 10,120,200,40"},
+		},
+		{
+			name:  "separate columns are aligned separately",
+			xml:   page("", "Name:10,0,200,40", "Email:300,0,200,40", "Phone:10,60,200,40"),
+			rules: Rules{Alignment: defaults.Alignment},
+		},
+		{
+			name:  "uneven gap in a column",
+			xml:   page("", "Name:10,0,200,40", "Email:10,60,200,40", "Phone:10,120,200,40", "Notes:10,200,200,40"),
+			rules: Rules{Spacing: defaults.Spacing},
+			want:  []string{"[Notes Phone] → 10,180,200,40"},
+		},
+		{
+			name:  "landmarks are left out of spacing",
+			xml:   page("", "Navbar:10,0,200,40", "Name:10,100,200,40", "Email:10,160,200,40", "Phone:10,220,200,40"),
+			rules: Rules{Spacing: defaults.Spacing},
+		},
+		{
+			name:  "grid is off by default",
+			xml:   page("", "Name:13,7,195,41"),
+			rules: Rules{Grid: defaults.Grid},
+		},
+		{
+			name:  "off-grid element snaps position and grows size",
+			xml:   page("", "Name:13,7,195,41"),
+			rules: Rules{Grid: grid},
+			want:  []string{"[Name] → 10,10,200,50"},
+		},
+		{
+			name:  "navbar narrower than the layout",
+			xml:   page("", "Navbar:100,0,500,60", "Content:0,80,800,400"),
+			rules: Rules{FullWidth: defaults.FullWidth},
+			want:  []string{"[Navbar] → 0,0,800,60"},
+		},
+		{
+			name:  "full-width footer",
+			xml:   page("", "Content:0,0,800,400", "Footer:1,420,798,60"),
+			rules: Rules{FullWidth: defaults.FullWidth},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags, err := ValidateWith(tt.xml, tt.rules)
+			if err != nil {
+				t.Fatalf("ValidateWith: %v", err)
+			}
+			var got []string
+			for _, d := range diags {
+				s := d.Suggestion
+				got = append(got, fmt.Sprintf("%v → %g,%g,%g,%g", d.CellIDs, s.X, s.Y, s.Width, s.Height))
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("diagnostics = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// src/validator/bounds.go
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"holoplan-cli/src/taxonomy"
+)
+
+// ──────────────────────────────────────────────
+// 📦 RULE: Element Size
+// ──────────────────────────────────────────────
+
+func checkSizes(cv *canvas, rule SizeRule) []Diagnostic {
+	var diags []Diagnostic
+	for _, c := range cv.cells {
+		if c.Geometry.Width <= 0 || c.Geometry.Height <= 0 {
+			diags = append(diags, newDiagnostic(RuleSize, rule.Severity,
+				fmt.Sprintf("%s has a zero or negative size (%.0fx%.0f)",
+					describe(c), c.Geometry.Width, c.Geometry.Height), c))
+		}
+	}
+	return diags
+}
+
+// ──────────────────────────────────────────────
+// 🖼️ RULE: Canvas Bounds
+// ──────────────────────────────────────────────
+
+// checkBounds keeps elements on the page. The page size comes from the
+// mxGraphModel pageWidth/pageHeight attributes, falling back to the rule's
+// defaults. Height is only enforced when asked, since pages usually scroll.
+func checkBounds(cv *canvas, rule BoundsRule) []Diagnostic {
+	pageWidth := cv.pageWidth
+	if pageWidth <= 0 {
+		pageWidth = rule.PageWidth
+	}
+	pageHeight := cv.pageHeight
+	if pageHeight <= 0 {
+		pageHeight = rule.PageHeight
+	}
+
+	var diags []Diagnostic
+	for _, c := range cv.cells {
+		g := c.Geometry
+		var problems []string
+
+		if g.X < 0 || g.Y < 0 {
+			problems = append(problems, fmt.Sprintf("has negative coordinates (%.0f, %.0f)", g.X, g.Y))
+		}
+		if pageWidth > 0 && g.X+g.Width > pageWidth {
+			problems = append(problems, fmt.Sprintf("extends past the page width (%.0f > %.0f)", g.X+g.Width, pageWidth))
+		}
+		if rule.EnforceHeight && pageHeight > 0 && g.Y+g.Height > pageHeight {
+			problems = append(problems, fmt.Sprintf("extends past the page height (%.0f > %.0f)", g.Y+g.Height, pageHeight))
+		}
+
+		if len(problems) > 0 {
+			diags = append(diags, newDiagnostic(RuleBounds, rule.Severity,
+				fmt.Sprintf("%s %s", describe(c), strings.Join(problems, " and ")), c))
+		}
+	}
+	return diags
+}
+
+// ──────────────────────────────────────────────
+// 🪆 RULE: Parent Containment
+// ──────────────────────────────────────────────
+
+// checkContainment requires children of a vertex container to lie inside it.
+func checkContainment(cv *canvas, rule ContainmentRule) []Diagnostic {
+	var diags []Diagnostic
+	for _, c := range cv.cells {
+		parent, ok := cv.byID[c.Parent]
+		if !ok || parent.Vertex != "1" {
+			continue
+		}
+		if !contains(parent, c) {
+			diags = append(diags, newDiagnostic(RuleContainment, rule.Severity,
+				fmt.Sprintf("%s spills outside its container %s", describe(c), describe(parent)),
+				c, parent))
+		}
+	}
+	return diags
+}
+
+// contains reports whether b lies entirely inside a (edges may touch).
+func contains(a, b mxCell) bool {
+	return b.Geometry.X >= a.Geometry.X &&
+		b.Geometry.Y >= a.Geometry.Y &&
+		b.Geometry.X+b.Geometry.Width <= a.Geometry.X+a.Geometry.Width &&
+		b.Geometry.Y+b.Geometry.Height <= a.Geometry.Y+a.Geometry.Height
+}
+
+// ──────────────────────────────────────────────
+// 🙈 RULE: Hidden Elements
+// ──────────────────────────────────────────────
+
+// checkHidden flags elements entirely covered by an opaque, unrelated element
+// that is drawn after them (later cells paint on top in Draw.io).
+func checkHidden(cv *canvas, rule HiddenRule) []Diagnostic {
+	var diags []Diagnostic
+	for i, under := range cv.cells {
+		for j := i + 1; j < len(cv.cells); j++ {
+			over := cv.cells[j]
+			if over.ID == under.ID || !isOpaque(over) || cv.related(under, over) || !contains(over, under) {
+				continue
+			}
+			diags = append(diags, newDiagnostic(RuleHidden, rule.Severity,
+				fmt.Sprintf("%s is hidden behind %s", describe(under), describe(over)),
+				under, over))
+			break
+		}
+	}
+	return diags
+}
+
+// isOpaque reports whether a cell paints a background that hides what's beneath.
+func isOpaque(c mxCell) bool {
+	styles := taxonomy.ParseStyle(c.Style)
+	if fill, ok := styles["fillColor"]; ok && strings.EqualFold(fill, "none") {
+		return false
+	}
+	if _, ok := styles["text"]; ok {
+		return false
+	}
+	return styles["opacity"] != "0"
+}
@@ -0,0 +1,116 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestBoundsAndContainment(t *testing.T) {
+	defaults := DefaultRules()
+	base := Rules{Size: defaults.Size, Bounds: defaults.Bounds, Containment: defaults.Containment, Hidden: defaults.Hidden}
+	enforceHeight := base
+	enforceHeight.Bounds.EnforceHeight = true
+	noFallback := base
+	noFallback.Bounds.PageWidth = 0
+
+	withPage := func(xml string) string {
+		return strings.Replace(xml, "<mxGraphModel>", `<mxGraphModel pageWidth="400" pageHeight="300">`, 1)
+	}
+	styled := func(id, style string, x, y, w, h float64) string {
+		return fmt.Sprintf(`<mxCell id="%s" style="%s" vertex="1" parent="1"><mxGeometry x="%g" y="%g" width="%g" height="%g" as="geometry"/></mxCell>`, id, style, x, y, w, h)
+	}
+	model := func(cells ...string) string {
+		return `<mxGraphModel><root><mxCell id="0"/><mxCell id="1" parent="0"/>` + strings.Join(cells, "") + `</root></mxGraphModel>`
+	}
+
+	tests := []struct {
+		name  string
+		xml   string
+		rules Rules
+		want  []string // "rule:cell IDs" of each diagnostic
+	}{
+		{
+			name:  "zero size",
+			xml:   drawio("a:1:0,0,0,40"),
+			rules: base,
+			want:  []string{"size:[a]"},
+		},
+		{
+			name:  "negative coordinates",
+			xml:   drawio("a:1:-5,10,100,40"),
+			rules: base,
+			want:  []string{"bounds:[a]"},
+		},
+		{
+			name:  "declared page width wins over the rule's",
+			xml:   withPage(drawio("a:1:0,0,300,40", "b:1:350,0,100,40")),
+			rules: base,
+			want:  []string{"bounds:[b]"},
+		},
+		{
+			name:  "rule page width applies when none is declared",
+			xml:   drawio("a:1:800,0,100,40"),
+			rules: base,
+			want:  []string{"bounds:[a]"},
+		},
+		{
+			name:  "no page width at all disables the check",
+			xml:   drawio("a:1:800,0,100,40"),
+			rules: noFallback,
+		},
+		{
+			name:  "page height only when enforced",
+			xml:   withPage(drawio("a:1:0,280,100,40")),
+			rules: base,
+		},
+		{
+			name:  "page height when enforced",
+			xml:   withPage(drawio("a:1:0,280,100,40")),
+			rules: enforceHeight,
+			want:  []string{"bounds:[a]"},
+		},
+		{
+			name:  "child inside its container",
+			xml:   drawio("card:1:0,0,200,100", "title:card:10,10,100,20"),
+			rules: base,
+		},
+		{
+			name:  "child spilling out of its container",
+			xml:   drawio("card:1:0,0,200,100", "title:card:150,10,100,20"),
+			rules: base,
+			want:  []string{"containment:[title card]"},
+		},
+		{
+			name:  "opaque element drawn over another hides it",
+			xml:   model(styled("under", "", 20, 20, 50, 20), styled("over", "fillColor=#ffffff;", 0, 0, 200, 100)),
+			rules: base,
+			want:  []string{"hidden:[under over]"},
+		},
+		{
+			name:  "transparent element hides nothing",
+			xml:   model(styled("under", "", 20, 20, 50, 20), styled("over", "fillColor=none;", 0, 0, 200, 100)),
+			rules: base,
+		},
+		{
+			name:  "element drawn first is behind, not hiding",
+			xml:   model(styled("over", "", 0, 0, 200, 100), styled("under", "", 20, 20, 50, 20)),
+			rules: base,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags, err := ValidateWith(tt.xml, tt.rules)
+			if err != nil {
+				t.Fatalf("ValidateWith: %v", err)
+			}
+			var got []string
+			for _, d := range diags {
+				got = append(got, fmt.Sprintf("%s:%v", d.Rule, d.CellIDs))
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("diagnostics = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// src/validator/diagnostic.go
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Severity grades how serious a diagnostic is.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Rule IDs reported in Diagnostic.Rule
+const (
+	RuleParse        = "parse"
+	RuleStructure    = "structure"
+	RuleCollision    = "collision"
+	RuleVerticalFlow = "vertical-flow"
+	RuleSemanticZone = "semantic-zone"
+	RuleSize         = "size"
+	RuleBounds       = "bounds"
+	RuleContainment  = "containment"
+	RuleHidden       = "hidden"
+	RuleEdge         = "edge"
+	RuleAlignment    = "alignment"
+	RuleSpacing      = "spacing"
+	RuleGrid         = "grid"
+	RuleFullWidth    = "full-width"
+	RuleTextFit      = "text-fit"
+	RuleMinSize      = "min-size"
+	RuleFigmaSchema  = "figma-schema"
+)
+
+// Rect is an absolute bounding box on the canvas.
+type Rect struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// Diagnostic is a single rule violation found by the validator.
+type Diagnostic struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	CellIDs  []string `json:"cell_ids"`
+	Labels   []string `json:"labels"`
+	Bounds   []Rect   `json:"bounds"`
+	Message  string   `json:"message"`
+
+	// Suggestion is the geometry that would fix the first cell, for rules
+	// with a mechanical fix
+	Suggestion *Rect `json:"suggestion,omitempty"`
+}
+
+func newDiagnostic(rule string, severity Severity, message string, cells ...mxCell) Diagnostic {
+	d := Diagnostic{
+		Rule:     rule,
+		Severity: severity,
+		CellIDs:  []string{},
+		Labels:   []string{},
+		Bounds:   []Rect{},
+		Message:  message,
+	}
+	for _, c := range cells {
+		d.CellIDs = append(d.CellIDs, c.ID)
+		d.Labels = append(d.Labels, c.Value)
+		d.Bounds = append(d.Bounds, Rect{
+			X:      c.Geometry.X,
+			Y:      c.Geometry.Y,
+			Width:  c.Geometry.Width,
+			Height: c.Geometry.Height,
+		})
+	}
+	return d
+}
+
+// HasErrors reports whether any diagnostic has error severity.
+func HasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns the number of diagnostics with the given severity.
+func Count(diags []Diagnostic, severity Severity) int {
+	n := 0
+	for _, d := range diags {
+		if d.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+var ruleIcons = map[string]string{
+	RuleParse:        "🧱",
+	RuleStructure:    "🏗️",
+	RuleCollision:    "🚫",
+	RuleVerticalFlow: "↕️",
+	RuleSemanticZone: "🧭",
+	RuleSize:         "📦",
+	RuleBounds:       "🖼️",
+	RuleContainment:  "🪆",
+	RuleHidden:       "🙈",
+	RuleEdge:         "🔗",
+	RuleAlignment:    "📏",
+	RuleSpacing:      "↔️",
+	RuleGrid:         "#️⃣",
+	RuleFullWidth:    "⬌",
+	RuleTextFit:      "🔤",
+	RuleMinSize:      "👆",
+	RuleFigmaSchema:  "🎨",
+}
+
+// RenderText writes one human-readable line per diagnostic.
+func RenderText(w io.Writer, diags []Diagnostic) error {
+	for _, d := range diags {
+		icon, ok := ruleIcons[d.Rule]
+		if !ok {
+			icon = "•"
+		}
+
+		var where []string
+		for i, id := range d.CellIDs {
+			loc := id
+			if i < len(d.Labels) && d.Labels[i] != "" {
+				loc = fmt.Sprintf("%q (%s)", d.Labels[i], id)
+			}
+			if i < len(d.Bounds) {
+				b := d.Bounds[i]
+				loc += fmt.Sprintf(" @ %.0f,%.0f %.0fx%.0f", b.X, b.Y, b.Width, b.Height)
+			}
+			where = append(where, loc)
+		}
+
+		line := fmt.Sprintf("%s [%s] %s: %s", icon, d.Severity, d.Rule, d.Message)
+		if len(where) > 0 {
+			line += " — " + strings.Join(where, "; ")
+		}
+		if s := d.Suggestion; s != nil {
+			line += fmt.Sprintf(" → suggest %.0f,%.0f %.0fx%.0f", s.X, s.Y, s.Width, s.Height)
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderJSON writes the diagnostics as an indented JSON array.
+func RenderJSON(w io.Writer, diags []Diagnostic) error {
+	if diags == nil {
+		diags = []Diagnostic{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(diags)
+}
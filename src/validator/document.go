@@ -0,0 +1,210 @@
+// src/validator/document.go
+package validator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"holoplan-cli/src/shared"
+
+	"github.com/beevik/etree"
+)
+
+// Page is a single <mxGraphModel> extracted from a Draw.io document.
+type Page struct {
+	Name string
+	XML  string
+}
+
+// PageResult is the validation outcome for one page. A page that cannot be
+// parsed yields a single RuleParse diagnostic.
+type PageResult struct {
+	Page        string       `json:"page"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// CheckFile validates every page of a .drawio file, or a Figma .json file as
+// a single page.
+func CheckFile(path string, rules Rules) ([]PageResult, error) {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return CheckFigmaFile(path, rules)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return CheckDocument(string(data), rules)
+}
+
+// CheckDocument validates a bare <mxGraphModel> or each <diagram> of an <mxfile>.
+func CheckDocument(raw string, rules Rules) ([]PageResult, error) {
+	pages, err := ExtractPages(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []PageResult
+	for _, page := range pages {
+		debugLog("📄 Page: %s\n", page.Name)
+		diags, err := ValidateWith(page.XML, rules)
+		if err != nil {
+			diags = []Diagnostic{newDiagnostic(RuleParse, SeverityError, err.Error())}
+		}
+		if diags == nil {
+			diags = []Diagnostic{}
+		}
+		results = append(results, PageResult{Page: page.Name, Diagnostics: diags})
+	}
+	return results, nil
+}
+
+// ExtractPages splits a Draw.io document into its pages. Compressed diagrams
+// (base64 + raw deflate + URL encoding, the diagrams.net default) are inflated,
+// and <object>/<UserObject> wrappers are flattened into plain mxCells.
+func ExtractPages(raw string) ([]Page, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromString(raw); err != nil {
+		return nil, fmt.Errorf("failed to parse document: %w", err)
+	}
+
+	models, err := PageModels(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var pages []Page
+	for _, m := range models {
+		xml, err := pageXML(m.Model)
+		if err != nil {
+			return nil, fmt.Errorf("page %q: %w", m.Name, err)
+		}
+		pages = append(pages, Page{Name: m.Name, XML: xml})
+	}
+	return pages, nil
+}
+
+// PageModel is one page of a parsed Draw.io document, editable in place.
+type PageModel struct {
+	Name  string
+	Model *etree.Element
+}
+
+// PageModels returns the <mxGraphModel> of a bare model document or of every
+// <diagram> in an <mxfile>. Compressed pages are inflated and stored back into
+// their <diagram> uncompressed, so edits to Model are kept when doc is written.
+func PageModels(doc *etree.Document) ([]PageModel, error) {
+	root := doc.Root()
+	if root == nil {
+		return nil, fmt.Errorf("document has no root element")
+	}
+
+	switch root.Tag {
+	case "mxGraphModel":
+		return []PageModel{{Name: "Page-1", Model: root}}, nil
+
+	case "mxfile":
+		var pages []PageModel
+		for i, diagram := range root.SelectElements("diagram") {
+			name := diagram.SelectAttrValue("name", fmt.Sprintf("Page-%d", i+1))
+
+			model := diagram.SelectElement("mxGraphModel")
+			if model == nil {
+				inflated, err := shared.InflateDiagram(diagram.Text())
+				if err != nil {
+					return nil, fmt.Errorf("page %q: %w", name, err)
+				}
+				sub := etree.NewDocument()
+				if err := sub.ReadFromString(inflated); err != nil {
+					return nil, fmt.Errorf("page %q: failed to parse inflated diagram: %w", name, err)
+				}
+				model = sub.Root()
+				if model == nil || model.Tag != "mxGraphModel" {
+					return nil, fmt.Errorf("page %q: no <mxGraphModel> found", name)
+				}
+				diagram.SetText("")
+				diagram.AddChild(model)
+			}
+			pages = append(pages, PageModel{Name: name, Model: model})
+		}
+		if len(pages) == 0 {
+			return nil, fmt.Errorf("<mxfile> contains no <diagram> pages")
+		}
+		return pages, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported root element <%s>", root.Tag)
+	}
+}
+
+// PageRepair lists the structural fixes applied to one page.
+type PageRepair struct {
+	Page  string
+	Fixes []string
+}
+
+// RepairDocument applies Repair to a bare <mxGraphModel> or to every page of an
+// <mxfile>. Compressed pages are written back uncompressed, which diagrams.net
+// reads just as well.
+func RepairDocument(raw string) (string, []PageRepair, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromString(raw); err != nil {
+		return "", nil, fmt.Errorf("failed to parse document: %w", err)
+	}
+
+	pages, err := PageModels(doc)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var repairs []PageRepair
+	for _, page := range pages {
+		repairs = append(repairs, PageRepair{Page: page.Name, Fixes: repairModel(page.Model)})
+	}
+
+	doc.Indent(2)
+	out, err := doc.WriteToString()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to serialize repaired document: %w", err)
+	}
+	return out, repairs, nil
+}
+
+// pageXML serializes a single <mxGraphModel> after flattening wrapper elements.
+func pageXML(model *etree.Element) (string, error) {
+	doc := etree.NewDocument()
+	doc.SetRoot(model.Copy())
+	flattenWrappers(doc.Root())
+	return doc.WriteToString()
+}
+
+// flattenWrappers replaces <object>/<UserObject> elements, which diagrams.net
+// uses for cells with custom properties, by their inner mxCell.
+func flattenWrappers(model *etree.Element) {
+	root := model.SelectElement("root")
+	if root == nil {
+		return
+	}
+
+	for _, wrapper := range root.ChildElements() {
+		if wrapper.Tag != "object" && wrapper.Tag != "UserObject" {
+			continue
+		}
+		cell := wrapper.SelectElement("mxCell")
+		if cell == nil {
+			root.RemoveChild(wrapper)
+			continue
+		}
+
+		cell = cell.Copy()
+		cell.CreateAttr("id", wrapper.SelectAttrValue("id", ""))
+		if label := wrapper.SelectAttr("label"); label != nil {
+			cell.CreateAttr("value", label.Value)
+		}
+
+		root.InsertChildAt(wrapper.Index(), cell)
+		root.RemoveChild(wrapper)
+	}
+}
@@ -0,0 +1,49 @@
+// src/validator/edges.go
+package validator
+
+import (
+	"fmt"
+)
+
+// ──────────────────────────────────────────────
+// 🔗 RULE: Edge Integrity
+// ──────────────────────────────────────────────
+
+// checkEdges verifies connectors: each end is either attached to an existing
+// vertex or pinned to a free point, and an edge only loops back to its own
+// source when the rule allows it.
+func checkEdges(cv *canvas, rule EdgeRule) []Diagnostic {
+	var diags []Diagnostic
+	report := func(msg string, cells ...mxCell) {
+		diags = append(diags, newDiagnostic(RuleEdge, rule.Severity, msg, cells...))
+	}
+
+	for _, e := range cv.edges {
+		for _, end := range []struct {
+			name, ref, point string
+		}{
+			{"source", e.Source, "sourcePoint"},
+			{"target", e.Target, "targetPoint"},
+		} {
+			if end.ref == "" {
+				if !e.hasPoint(end.point) {
+					report(fmt.Sprintf("edge %s has no %s cell or %s", describe(e), end.name, end.point), e)
+				}
+				continue
+			}
+
+			v, ok := cv.byID[end.ref]
+			switch {
+			case !ok:
+				report(fmt.Sprintf("edge %s references missing %s %q", describe(e), end.name, end.ref), e)
+			case v.Vertex != "1":
+				report(fmt.Sprintf("edge %s %s %s is not a vertex", describe(e), end.name, describe(v)), e, v)
+			}
+		}
+
+		if e.Source != "" && e.Source == e.Target && !rule.AllowSelfLoops {
+			report(fmt.Sprintf("edge %s loops back to its own source %q", describe(e), e.Source), e)
+		}
+	}
+	return diags
+}
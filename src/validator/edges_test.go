@@ -0,0 +1,82 @@
+package validator
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEdges(t *testing.T) {
+	const vertices = `<mxCell id="a" value="A" vertex="1" parent="1"><mxGeometry x="0" y="0" width="100" height="40" as="geometry"/></mxCell>` +
+		`<mxCell id="b" value="B" vertex="1" parent="1"><mxGeometry x="0" y="200" width="100" height="40" as="geometry"/></mxCell>`
+	edge := func(attrs, points string) string {
+		return fmt.Sprintf(`<mxCell id="e" edge="1" parent="1" %s><mxGeometry relative="1" as="geometry">%s</mxGeometry></mxCell>`, attrs, points)
+	}
+	loops := DefaultRules()
+	loops.Edge.AllowSelfLoops = true
+
+	tests := []struct {
+		name  string
+		edge  string
+		rules Rules
+		want  []string // messages of the edge diagnostics
+	}{
+		{
+			name: "attached at both ends",
+			edge: edge(`source="a" target="b"`, ""),
+		},
+		{
+			name: "free ends pinned to points",
+			edge: edge(`source="a"`, `<mxPoint x="50" y="300" as="targetPoint"/>`),
+		},
+		{
+			name: "free end with no point",
+			edge: edge(`source="a"`, ""),
+			want: []string{"edge (e) has no target cell or targetPoint"},
+		},
+		{
+			name: "missing endpoint",
+			edge: edge(`source="a" target="ghost"`, ""),
+			want: []string{`edge (e) references missing target "ghost"`},
+		},
+		{
+			name: "endpoint that is not a vertex",
+			edge: edge(`source="1" target="b"`, ""),
+			want: []string{"edge (e) source (1) is not a vertex"},
+		},
+		{
+			name: "self loop",
+			edge: edge(`source="a" target="a"`, ""),
+			want: []string{`edge (e) loops back to its own source "a"`},
+		},
+		{
+			name:  "self loop when allowed",
+			edge:  edge(`source="a" target="a"`, ""),
+			rules: loops,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := tt.rules
+			if !rules.Edge.Enabled {
+				rules = DefaultRules()
+			}
+			xml := `<mxGraphModel><root><mxCell id="0"/><mxCell id="1" parent="0"/>` + vertices + tt.edge + `</root></mxGraphModel>`
+			diags, err := ValidateWith(xml, Rules{Edge: rules.Edge, Collision: rules.Collision})
+			if err != nil {
+				t.Fatalf("ValidateWith: %v", err)
+			}
+			// Edges cross vertices freely; only the edge rule may report
+			var got []string
+			for _, d := range diags {
+				got = append(got, d.Rule+": "+d.Message)
+			}
+			var want []string
+			for _, m := range tt.want {
+				want = append(want, RuleEdge+": "+m)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("diagnostics = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,356 @@
+// src/validator/figma.go
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"holoplan-cli/src/ir"
+)
+
+// figmaTypes are the node types the Figma builder prompt allows.
+var figmaTypes = map[string]bool{
+	"FRAME":     true,
+	"RECTANGLE": true,
+	"TEXT":      true,
+	"GROUP":     true,
+	"COMPONENT": true,
+}
+
+// figmaContainers are the node types that may have children.
+var figmaContainers = map[string]bool{
+	"FRAME":     true,
+	"GROUP":     true,
+	"COMPONENT": true,
+}
+
+// figmaNode is a node of a Figma document as decoded by encoding/json, kept
+// untyped so that wrong field types are reported instead of failing decoding.
+type figmaNode map[string]any
+
+func (n figmaNode) str(key string) (string, bool) {
+	s, ok := n[key].(string)
+	return s, ok
+}
+
+// bounds returns the node's absoluteBoundingBox when all four fields are numbers.
+func (n figmaNode) bounds() (Rect, bool) {
+	box, ok := n["absoluteBoundingBox"].(map[string]any)
+	if !ok {
+		return Rect{}, false
+	}
+	var r Rect
+	for key, dst := range map[string]*float64{"x": &r.X, "y": &r.Y, "width": &r.Width, "height": &r.Height} {
+		f, ok := box[key].(float64)
+		if !ok {
+			return Rect{}, false
+		}
+		*dst = f
+	}
+	return r, true
+}
+
+// CheckFigmaFile validates a .figma.json file: a single view as one page, or a
+// merged document with one page per CANVAS.
+func CheckFigmaFile(path string, rules Rules) ([]PageResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file map[string]any
+	if err := json.Unmarshal(data, &file); err == nil {
+		if doc, ok := file["document"].(map[string]any); ok {
+			if t, _ := figmaNode(doc).str("type"); t == "DOCUMENT" {
+				return checkFigmaDocument(string(data), file, figmaNode(doc), rules), nil
+			}
+		}
+	}
+
+	diags, err := ValidateFigma(string(data), rules)
+	if err != nil {
+		diags = []Diagnostic{newDiagnostic(RuleParse, SeverityError, err.Error())}
+	}
+	if diags == nil {
+		diags = []Diagnostic{}
+	}
+	return []PageResult{{Page: "document", Diagnostics: diags}}, nil
+}
+
+// checkFigmaDocument validates each CANVAS of a merged document as a page.
+// Node IDs must be unique across the whole file, and each page holds the
+// root FRAMEs of its views.
+func checkFigmaDocument(raw string, file map[string]any, doc figmaNode, rules Rules) []PageResult {
+	var layouts []*ir.Page
+	if converted, err := ir.FromFigma(raw); err == nil {
+		layouts = converted.Pages
+	}
+
+	var results []PageResult
+	if rules.FigmaSchema.Enabled {
+		if diags := checkFigmaHeader(file, rules.FigmaSchema); len(diags) > 0 {
+			results = append(results, PageResult{Page: "document", Diagnostics: diags})
+		}
+	}
+
+	seen := make(map[string]string)
+	pages, _ := doc["children"].([]any)
+	page := 0
+	for i, p := range pages {
+		path := fmt.Sprintf("document.children[%d]", i)
+		raw, ok := p.(map[string]any)
+		if !ok {
+			continue
+		}
+		// ir.FromFigma converts the same CANVAS objects in the same order
+		var layout *ir.Page
+		if page < len(layouts) {
+			layout = layouts[page]
+		}
+		page++
+		canvas := figmaNode(raw)
+		name, _ := canvas.str("name")
+		if name == "" {
+			name = path
+		}
+
+		diags := []Diagnostic{}
+		if t, _ := canvas.str("type"); t != "CANVAS" && rules.FigmaSchema.Enabled {
+			diags = append(diags, figmaDiagnostic(rules.FigmaSchema, path, canvas,
+				fmt.Sprintf("page %s must be a CANVAS, got %q", figmaDescribe(path, canvas), t)))
+		}
+
+		frames, _ := canvas["children"].([]any)
+		for j, f := range frames {
+			framePath := fmt.Sprintf("%s.children[%d]", path, j)
+			raw, ok := f.(map[string]any)
+			if !ok {
+				continue
+			}
+			if rules.FigmaSchema.Enabled {
+				diags = append(diags, checkFigmaTree(framePath, figmaNode(raw), "", seen, rules.FigmaSchema)...)
+			}
+		}
+		if layout != nil {
+			diags = append(diags, ValidatePage(layout, rules)...)
+		}
+		results = append(results, PageResult{Page: name, Diagnostics: diags})
+	}
+	return results
+}
+
+// ──────────────────────────────────────────────
+// 🎨 RULE: Figma Schema
+// ──────────────────────────────────────────────
+
+// ValidateFigma checks Figma builder output against the node rules of the
+// Figma builder prompt, then converts it to the IR and runs the layout rules
+// on the nodes' bounding boxes, and reports every violation. The error is non-nil only if the input
+// is not a JSON object.
+func ValidateFigma(raw string, rules Rules) ([]Diagnostic, error) {
+	var file map[string]any
+	if err := json.Unmarshal([]byte(raw), &file); err != nil {
+		return nil, fmt.Errorf("invalid Figma JSON: %w", err)
+	}
+
+	var diags []Diagnostic
+	if rules.FigmaSchema.Enabled {
+		diags = append(diags, checkFigmaSchema(file, rules.FigmaSchema)...)
+	}
+	if doc, err := ir.FromFigma(raw); err == nil {
+		for _, page := range doc.Pages {
+			diags = append(diags, ValidatePage(page, rules)...)
+		}
+	}
+	return diags, nil
+}
+
+func checkFigmaSchema(file map[string]any, rule FigmaSchemaRule) []Diagnostic {
+	diags := checkFigmaHeader(file, rule)
+	doc, ok := file["document"].(map[string]any)
+	if !ok {
+		return append(diags, figmaDiagnostic(rule, "", nil, "missing top-level \"document\" node"))
+	}
+	return append(diags, checkFigmaTree("document", figmaNode(doc), "0:1", make(map[string]string), rule)...)
+}
+
+// figmaDiagnostic reports a schema problem with node n, identified by its id
+// or, lacking one, by its path.
+func figmaDiagnostic(rule FigmaSchemaRule, path string, n figmaNode, msg string) Diagnostic {
+	d := newDiagnostic(RuleFigmaSchema, rule.Severity, msg)
+	if n != nil {
+		id, ok := n.str("id")
+		if !ok || id == "" {
+			id = path
+		}
+		name, _ := n.str("name")
+		d.CellIDs = append(d.CellIDs, id)
+		d.Labels = append(d.Labels, name)
+		if b, ok := n.bounds(); ok {
+			d.Bounds = append(d.Bounds, b)
+		}
+	}
+	return d
+}
+
+// checkFigmaHeader checks the top-level fields next to "document".
+func checkFigmaHeader(file map[string]any, rule FigmaSchemaRule) []Diagnostic {
+	var diags []Diagnostic
+	if _, ok := file["schemaVersion"].(float64); !ok {
+		diags = append(diags, figmaDiagnostic(rule, "", nil, "missing numeric top-level \"schemaVersion\""))
+	}
+	for _, key := range []string{"components", "styles"} {
+		if _, ok := file[key].(map[string]any); !ok {
+			diags = append(diags, figmaDiagnostic(rule, "", nil, fmt.Sprintf("missing top-level %q object", key)))
+		}
+	}
+	return diags
+}
+
+// checkFigmaTree checks a view's root FRAME and every node below it. rootID
+// is the id the root must have, or "" for any; seen maps the ids in use to
+// their paths, so merged documents can share it across pages.
+func checkFigmaTree(rootPath string, root figmaNode, rootID string, seen map[string]string, rule FigmaSchemaRule) []Diagnostic {
+	var diags []Diagnostic
+	report := func(path string, n figmaNode, format string, args ...any) {
+		diags = append(diags, figmaDiagnostic(rule, path, n, fmt.Sprintf(format, args...)))
+	}
+
+	if t, _ := root.str("type"); t != "FRAME" {
+		report(rootPath, root, "root node must be a FRAME, got %q", t)
+	}
+	if id, _ := root.str("id"); rootID != "" && id != rootID {
+		report(rootPath, root, "root node must have id %q, got %q", rootID, id)
+	}
+
+	var visit func(path string, n figmaNode)
+	visit = func(path string, n figmaNode) {
+		id, ok := n.str("id")
+		switch {
+		case !ok || id == "":
+			report(path, n, "node at %s has no \"id\"", path)
+		case seen[id] != "":
+			report(path, n, "duplicate node id %q (first used at %s)", id, seen[id])
+		default:
+			seen[id] = path
+		}
+
+		if name, ok := n.str("name"); !ok || strings.TrimSpace(name) == "" {
+			report(path, n, "node %s has no \"name\"", figmaDescribe(path, n))
+		}
+
+		t, _ := n.str("type")
+		if !figmaTypes[t] {
+			report(path, n, "node %s has invalid type %q (want FRAME, RECTANGLE, TEXT, GROUP or COMPONENT)",
+				figmaDescribe(path, n), t)
+		}
+
+		if b, ok := n.bounds(); !ok {
+			report(path, n, "node %s needs an \"absoluteBoundingBox\" with numeric x, y, width and height",
+				figmaDescribe(path, n))
+		} else if b.Width < 0 || b.Height < 0 {
+			report(path, n, "node %s has a negative size (%gx%g)", figmaDescribe(path, n), b.Width, b.Height)
+		}
+
+		if v, ok := n["visible"].(bool); !ok || !v {
+			report(path, n, "node %s must have \"visible\": true", figmaDescribe(path, n))
+		}
+
+		if t == "TEXT" {
+			if s, ok := n.str("characters"); !ok || strings.TrimSpace(s) == "" {
+				report(path, n, "TEXT node %s has no \"characters\"", figmaDescribe(path, n))
+			}
+		}
+
+		for _, problem := range figmaColorProblems(n) {
+			report(path, n, "node %s: %s", figmaDescribe(path, n), problem)
+		}
+
+		raw, has := n["children"]
+		if !has {
+			return
+		}
+		children, ok := raw.([]any)
+		if !ok {
+			report(path, n, "node %s: \"children\" must be an array", figmaDescribe(path, n))
+			return
+		}
+		if len(children) > 0 && figmaTypes[t] && !figmaContainers[t] {
+			report(path, n, "%s node %s cannot have children; wrap them in a FRAME or GROUP",
+				t, figmaDescribe(path, n))
+		}
+		for i, c := range children {
+			childPath := fmt.Sprintf("%s.children[%d]", path, i)
+			child, ok := c.(map[string]any)
+			if !ok {
+				report(childPath, nil, "child at %s is not an object", childPath)
+				continue
+			}
+			visit(childPath, figmaNode(child))
+		}
+	}
+	visit(rootPath, root)
+
+	return diags
+}
+
+// figmaColorProblems checks that backgroundColor and the colors of fills and
+// strokes are {r, g, b[, a]} objects with components between 0 and 1.
+func figmaColorProblems(n figmaNode) []string {
+	var problems []string
+	check := func(where string, v any) {
+		color, ok := v.(map[string]any)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s must be an {r, g, b, a} object", where))
+			return
+		}
+		for _, ch := range []string{"r", "g", "b", "a"} {
+			raw, has := color[ch]
+			if !has {
+				if ch != "a" {
+					problems = append(problems, fmt.Sprintf("%s is missing %q", where, ch))
+				}
+				continue
+			}
+			f, ok := raw.(float64)
+			if !ok || f < 0 || f > 1 {
+				problems = append(problems, fmt.Sprintf("%s.%s must be a number from 0 to 1, got %v", where, ch, raw))
+			}
+		}
+	}
+
+	if v, ok := n["backgroundColor"]; ok {
+		check("backgroundColor", v)
+	}
+	for _, key := range []string{"fills", "strokes"} {
+		paints, ok := n[key].([]any)
+		if !ok {
+			continue
+		}
+		for i, p := range paints {
+			if paint, ok := p.(map[string]any); ok {
+				if v, ok := paint["color"]; ok {
+					check(fmt.Sprintf("%s[%d].color", key, i), v)
+				}
+			}
+		}
+	}
+	return problems
+}
+
+// figmaDescribe renders a node as `"Name" (id)` for messages, or its path.
+func figmaDescribe(path string, n figmaNode) string {
+	name, _ := n.str("name")
+	id, _ := n.str("id")
+	switch {
+	case name != "" && id != "":
+		return fmt.Sprintf("%q (%s)", name, id)
+	case id != "":
+		return fmt.Sprintf("(%s)", id)
+	case name != "":
+		return fmt.Sprintf("%q at %s", name, path)
+	}
+	return path
+}
@@ -0,0 +1,134 @@
+package validator
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// figmaView returns a valid single-view Figma file: a root FRAME holding a
+// button RECTANGLE and its TEXT label.
+func figmaView() map[string]any {
+	node := func(id, name, typ string, x, y, w, h float64) map[string]any {
+		return map[string]any{
+			"id": id, "name": name, "type": typ, "visible": true,
+			"absoluteBoundingBox": map[string]any{"x": x, "y": y, "width": w, "height": h},
+		}
+	}
+	button := node("0:2", "Save", "RECTANGLE", 20, 20, 120, 44)
+	button["fills"] = []any{map[string]any{"type": "SOLID", "color": map[string]any{"r": 0.2, "g": 0.4, "b": 1.0, "a": 1.0}}}
+	label := node("0:3", "Save label", "TEXT", 20, 20, 120, 44)
+	label["characters"] = "Save"
+	root := node("0:1", "Home", "FRAME", 0, 0, 400, 300)
+	root["children"] = []any{button, label}
+	return map[string]any{"schemaVersion": 0.0, "components": map[string]any{}, "styles": map[string]any{}, "document": root}
+}
+
+// child returns the i-th child of the view's root frame.
+func child(f map[string]any, i int) map[string]any {
+	return f["document"].(map[string]any)["children"].([]any)[i].(map[string]any)
+}
+
+func TestFigmaSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(f map[string]any)
+		want   string // substring of the only diagnostic; empty for none
+	}{
+		{name: "valid view", modify: func(map[string]any) {}},
+		{name: "missing schemaVersion", modify: func(f map[string]any) { delete(f, "schemaVersion") }, want: `"schemaVersion"`},
+		{name: "missing styles", modify: func(f map[string]any) { delete(f, "styles") }, want: `top-level "styles" object`},
+		{name: "root is not a FRAME", modify: func(f map[string]any) { f["document"].(map[string]any)["type"] = "GROUP" }, want: `root node must be a FRAME, got "GROUP"`},
+		{name: "root id", modify: func(f map[string]any) { f["document"].(map[string]any)["id"] = "1:1" }, want: `root node must have id "0:1"`},
+		{name: "invalid type", modify: func(f map[string]any) { child(f, 0)["type"] = "ELLIPSE" }, want: `invalid type "ELLIPSE"`},
+		{name: "duplicate id", modify: func(f map[string]any) { child(f, 1)["id"] = "0:2" }, want: `duplicate node id "0:2"`},
+		{name: "missing name", modify: func(f map[string]any) { child(f, 0)["name"] = " " }, want: `has no "name"`},
+		{name: "string coordinates", modify: func(f map[string]any) {
+			child(f, 0)["absoluteBoundingBox"].(map[string]any)["x"] = "20"
+		}, want: `needs an "absoluteBoundingBox"`},
+		{name: "hidden node", modify: func(f map[string]any) { child(f, 0)["visible"] = false }, want: `must have "visible": true`},
+		{name: "TEXT without characters", modify: func(f map[string]any) { delete(child(f, 1), "characters") }, want: `TEXT node "Save label" (0:3) has no "characters"`},
+		{name: "color out of range", modify: func(f map[string]any) {
+			child(f, 0)["fills"].([]any)[0].(map[string]any)["color"].(map[string]any)["b"] = 255.0
+		}, want: "fills[0].color.b must be a number from 0 to 1, got 255"},
+		{name: "children under a RECTANGLE", modify: func(f map[string]any) {
+			child(f, 0)["children"] = []any{child(f, 1)}
+			f["document"].(map[string]any)["children"] = []any{child(f, 0)}
+		}, want: "RECTANGLE node \"Save\" (0:2) cannot have children"},
+	}
+	rules := Rules{FigmaSchema: DefaultRules().FigmaSchema}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := figmaView()
+			tt.modify(f)
+			raw, err := json.Marshal(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			diags, err := ValidateFigma(string(raw), rules)
+			if err != nil {
+				t.Fatalf("ValidateFigma: %v", err)
+			}
+			switch {
+			case tt.want == "" && len(diags) > 0:
+				t.Errorf("diagnostics = %v, want none", diags)
+			case tt.want != "" && (len(diags) != 1 || !strings.Contains(diags[0].Message, tt.want)):
+				t.Errorf("diagnostics = %v, want one with %q", diags, tt.want)
+			}
+		})
+	}
+
+	if _, err := ValidateFigma("[1, 2]", rules); err == nil {
+		t.Error("a JSON array was accepted as a Figma file")
+	}
+}
+
+func TestFigmaLayout(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(f map[string]any)
+		want   []string // rules reported, in order
+	}{
+		{name: "label on its button", modify: func(map[string]any) {}},
+		{name: "label before its button", modify: func(f map[string]any) {
+			root := f["document"].(map[string]any)
+			root["children"] = []any{child(f, 1), child(f, 0)}
+		}, want: []string{RuleCollision}},
+		{name: "overlapping rectangles", modify: func(f map[string]any) {
+			other := figmaView()
+			card := child(other, 0)
+			card["id"], card["name"] = "0:4", "Card"
+			card["absoluteBoundingBox"].(map[string]any)["x"] = 100.0
+			root := f["document"].(map[string]any)
+			root["children"] = append(root["children"].([]any), card)
+		}, want: []string{RuleCollision, RuleCollision}}, // the button and its label
+		{name: "button past the frame edge", modify: func(f map[string]any) {
+			child(f, 0)["absoluteBoundingBox"].(map[string]any)["x"] = 350.0
+			child(f, 1)["absoluteBoundingBox"].(map[string]any)["x"] = 350.0
+		}, want: []string{RuleBounds, RuleBounds}},
+	}
+	defaults := DefaultRules()
+	rules := Rules{FigmaSchema: defaults.FigmaSchema, Collision: defaults.Collision, Bounds: defaults.Bounds}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := figmaView()
+			tt.modify(f)
+			raw, err := json.Marshal(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			diags, err := ValidateFigma(string(raw), rules)
+			if err != nil {
+				t.Fatalf("ValidateFigma: %v", err)
+			}
+			var got []string
+			for _, d := range diags {
+				got = append(got, d.Rule)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("rules = %v, want %v (%v)", got, tt.want, diags)
+			}
+		})
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
-	"sort"
 	"strings"
 
+	"holoplan-cli/src/ir"
 	"holoplan-cli/src/shared"
+
+	"github.com/beevik/etree"
 )
 
 var Debug = false
@@ -24,43 +26,223 @@ type mxCell struct {
 	Value    string `xml:"value,attr"`
 	Style    string `xml:"style,attr"`
 	Vertex   string `xml:"vertex,attr"`
+	Edge     string `xml:"edge,attr"`
 	Parent   string `xml:"parent,attr"`
+	Source   string `xml:"source,attr"` // edges only
+	Target   string `xml:"target,attr"` // edges only
 	Geometry struct {
-		X      float64 `xml:"x,attr"`
-		Y      float64 `xml:"y,attr"`
-		Width  float64 `xml:"width,attr"`
-		Height float64 `xml:"height,attr"`
+		X      float64   `xml:"x,attr"`
+		Y      float64   `xml:"y,attr"`
+		Width  float64   `xml:"width,attr"`
+		Height float64   `xml:"height,attr"`
+		Points []mxPoint `xml:"mxPoint"` // edge endpoints when not attached
 	} `xml:"mxGeometry"`
+	Nested []mxCell `xml:"mxCell"` // invalid, reported by the structure rule
+}
+
+type mxPoint struct {
+	X  float64 `xml:"x,attr"`
+	Y  float64 `xml:"y,attr"`
+	As string  `xml:"as,attr"`
+}
+
+// hasPoint reports whether the cell's geometry has an mxPoint with the given role.
+func (c mxCell) hasPoint(as string) bool {
+	for _, p := range c.Geometry.Points {
+		if p.As == as {
+			return true
+		}
+	}
+	return false
 }
 
 type mxGraphModel struct {
-	Cells []mxCell `xml:"root>mxCell"`
+	PageWidth  float64  `xml:"pageWidth,attr"`
+	PageHeight float64  `xml:"pageHeight,attr"`
+	Cells      []mxCell `xml:"root>mxCell"`
+}
+
+// canvas is a flattened layout: vertex cells with absolute geometry in
+// drawing order (parents before children), the edges, and the page size.
+type canvas struct {
+	cells      []mxCell
+	edges      []mxCell
+	byID       map[string]mxCell
+	pageWidth  float64
+	pageHeight float64
+}
+
+// isAncestor reports whether cell a is an ancestor of cell b.
+func (cv *canvas) isAncestor(a, b mxCell) bool {
+	seen := map[string]bool{}
+	for p := b.Parent; p != "" && !seen[p]; {
+		if p == a.ID {
+			return true
+		}
+		seen[p] = true
+		parent, ok := cv.byID[p]
+		if !ok {
+			return false
+		}
+		p = parent.Parent
+	}
+	return false
 }
 
+// related reports whether one cell is nested inside the other.
+func (cv *canvas) related(a, b mxCell) bool {
+	return cv.isAncestor(a, b) || cv.isAncestor(b, a)
+}
+
+// CheckLayout validates a single <mxGraphModel> and returns an error summarizing
+// every error-severity diagnostic. Use Validate for the full diagnostic list.
 func CheckLayout(raw string) error {
+	return CheckLayoutWith(raw, DefaultRules())
+}
+
+// CheckLayoutWith is CheckLayout with a custom rule set.
+func CheckLayoutWith(raw string, rules Rules) error {
+	diags, err := ValidateWith(raw, rules)
+	if err != nil {
+		return err
+	}
+
+	var msgs []string
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			msgs = append(msgs, d.Message)
+		}
+	}
+	if len(msgs) > 0 {
+		return fmt.Errorf("%d layout error(s): %s", len(msgs), strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+// Validate runs every layout rule against a single <mxGraphModel> and returns
+// all violations found. The error is non-nil only if the XML cannot be parsed.
+func Validate(raw string) ([]Diagnostic, error) {
+	return ValidateWith(raw, DefaultRules())
+}
+
+// ValidateWith is Validate with a custom rule set.
+func ValidateWith(raw string, rules Rules) ([]Diagnostic, error) {
 	if strings.TrimSpace(raw) == "" {
-		return errors.New("❌ layout check aborted: input XML is empty or blank")
+		return nil, errors.New("layout check aborted: input XML is empty or blank")
 	}
 
-	sanitized, err := shared.SanitizeXML(raw)
+	// The sanitizer's fixes only make the layout readable; they are not findings
+	sanitized, _, err := shared.SanitizeXMLFixes(raw)
 	if err != nil {
-		return fmt.Errorf("❌ failed sanitizing XML: %w", err)
+		return nil, fmt.Errorf("failed sanitizing XML: %w", err)
+	}
+
+	// Flatten <object>/<UserObject> wrappers so their cells are seen
+	if flat := etree.NewDocument(); flat.ReadFromString(sanitized) == nil && flat.Root() != nil {
+		if flattened, err := pageXML(flat.Root()); err == nil {
+			sanitized = flattened
+		}
 	}
 
 	var model mxGraphModel
 	decoder := xml.NewDecoder(strings.NewReader(sanitized))
 	if err := decoder.Decode(&model); err != nil {
-		return fmt.Errorf("❌ XML parsing failed after sanitize: %w", err)
+		return nil, fmt.Errorf("XML parsing failed after sanitize: %w", err)
+	}
+
+	var diags []Diagnostic
+	if rules.Structure.Enabled {
+		diags = append(diags, checkStructure(model, rules.Structure)...)
+	}
+
+	doc, err := ir.FromDrawio(sanitized)
+	if err != nil {
+		return nil, err
+	}
+	for _, page := range doc.Pages {
+		diags = append(diags, ValidatePage(page, rules)...)
+	}
+	return diags, nil
+}
+
+// ValidatePage runs the geometry rules on a format-neutral page. Structural
+// and schema checks need the source format and are run by ValidateWith and
+// ValidateFigma.
+func ValidatePage(page *ir.Page, rules Rules) []Diagnostic {
+	return checkModel(cellModel(page), rules)
+}
+
+// cellModel lays a page out as Draw.io cells, the shape the rules work on:
+// frames become layers and widgets keep their parent-relative geometry, with
+// each frame's offset applied to its top-level widgets.
+func cellModel(page *ir.Page) mxGraphModel {
+	model := mxGraphModel{
+		PageWidth:  page.Width,
+		PageHeight: page.Height,
+		Cells:      []mxCell{{ID: "0"}},
 	}
 
+	var visit func(ws []*ir.Widget, parent string, ox, oy float64)
+	visit = func(ws []*ir.Widget, parent string, ox, oy float64) {
+		for _, w := range ws {
+			cell := mxCell{ID: w.ID, Value: w.Label, Style: w.DrawioStyle(), Vertex: "1", Parent: parent}
+			if w.Label == "" {
+				cell.Value = w.Name
+			}
+			cell.Geometry.X, cell.Geometry.Y = w.Geometry.X+ox, w.Geometry.Y+oy
+			cell.Geometry.Width, cell.Geometry.Height = w.Geometry.Width, w.Geometry.Height
+			model.Cells = append(model.Cells, cell)
+			visit(w.Children, w.ID, 0, 0)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range page.Frames {
+		if seen[f.ID] {
+			continue // its widgets are already under the first frame with the ID
+		}
+		seen[f.ID] = true
+		model.Cells = append(model.Cells, mxCell{ID: f.ID, Parent: "0"})
+		visit(f.Widgets, f.ID, f.Bounds.X, f.Bounds.Y)
+
+		for _, e := range f.Edges {
+			cell := mxCell{ID: e.ID, Value: e.Label, Style: e.Style.Raw, Edge: "1", Parent: f.ID, Source: e.Source, Target: e.Target}
+			if e.Parent != "" {
+				cell.Parent = e.Parent
+			}
+			if e.SourcePoint != nil {
+				cell.Geometry.Points = append(cell.Geometry.Points, mxPoint{X: e.SourcePoint.X, Y: e.SourcePoint.Y, As: "sourcePoint"})
+			}
+			if e.TargetPoint != nil {
+				cell.Geometry.Points = append(cell.Geometry.Points, mxPoint{X: e.TargetPoint.X, Y: e.TargetPoint.Y, As: "targetPoint"})
+			}
+			model.Cells = append(model.Cells, cell)
+		}
+	}
+	return model
+}
+
+// checkModel runs the geometry rules on a decoded model. Cell coordinates are
+// relative to their parent and are made absolute here.
+func checkModel(model mxGraphModel, rules Rules) []Diagnostic {
+	var diags []Diagnostic
+
 	// Build ID → cell map and group children
 	idMap := make(map[string]mxCell)
 	children := make(map[string][]mxCell)
 	var roots []mxCell
 
+	// A duplicate ID is reported by the structure rule; references resolve to
+	// the first cell with it, as in the IR, and its children are visited once
+	for _, cell := range model.Cells {
+		if _, dup := idMap[cell.ID]; !dup {
+			idMap[cell.ID] = cell
+		}
+	}
 	for _, cell := range model.Cells {
-		idMap[cell.ID] = cell
-		if cell.Parent != "" {
+		// Dangling or self references are reported by the structure rule;
+		// treat those cells as roots so their geometry is still checked
+		if _, ok := idMap[cell.Parent]; ok && cell.Parent != cell.ID {
 			children[cell.Parent] = append(children[cell.Parent], cell)
 		} else {
 			roots = append(roots, cell)
@@ -69,6 +251,7 @@ func CheckLayout(raw string) error {
 
 	// Flatten with absolute coordinates
 	var allRenderables []mxCell
+	expanded := make(map[string]bool)
 	var visit func(parent *mxCell, cell mxCell)
 	visit = func(parent *mxCell, cell mxCell) {
 		absCell := cell
@@ -77,6 +260,10 @@ func CheckLayout(raw string) error {
 			absCell.Geometry.Y += parent.Geometry.Y
 		}
 		allRenderables = append(allRenderables, absCell)
+		if expanded[cell.ID] {
+			return
+		}
+		expanded[cell.ID] = true
 
 		// Recurse into children
 		for _, child := range children[cell.ID] {
@@ -88,47 +275,107 @@ func CheckLayout(raw string) error {
 		visit(nil, root)
 	}
 
-	// Filter only vertex cells (renderable)
-	var renderables []mxCell
+	// Filter only vertex cells (renderable); edges are checked separately
+	var renderables, edges []mxCell
 	for _, c := range allRenderables {
-		if c.Vertex == "1" {
+		switch {
+		case c.Vertex == "1":
 			renderables = append(renderables, c)
+		case c.Edge == "1":
+			edges = append(edges, c)
 		}
 	}
 
-	fmt.Printf("🔎 Validating %d visible elements (with hierarchy)\n", len(renderables))
+	debugLog("🔎 Validating %d visible elements (with hierarchy)\n", len(renderables))
 
-	if err := checkCollisions(renderables); err != nil {
-		return err
+	cv := &canvas{
+		cells:      renderables,
+		edges:      edges,
+		byID:       make(map[string]mxCell),
+		pageWidth:  model.PageWidth,
+		pageHeight: model.PageHeight,
 	}
-	if err := checkVerticalFlow(renderables); err != nil {
-		return err
+	for _, c := range allRenderables {
+		if _, dup := cv.byID[c.ID]; !dup {
+			cv.byID[c.ID] = c
+		}
 	}
-	if err := checkSemanticZones(renderables); err != nil {
-		return err
+
+	if rules.Edge.Enabled {
+		diags = append(diags, checkEdges(cv, rules.Edge)...)
+	}
+	if rules.Size.Enabled {
+		diags = append(diags, checkSizes(cv, rules.Size)...)
+	}
+	if rules.Bounds.Enabled {
+		diags = append(diags, checkBounds(cv, rules.Bounds)...)
+	}
+	if rules.Containment.Enabled {
+		diags = append(diags, checkContainment(cv, rules.Containment)...)
+	}
+	if rules.Collision.Enabled {
+		diags = append(diags, checkCollisions(cv, rules.Collision)...)
+	}
+	if rules.Hidden.Enabled {
+		diags = append(diags, checkHidden(cv, rules.Hidden)...)
+	}
+	if rules.VerticalFlow.Enabled {
+		diags = append(diags, checkVerticalFlow(renderables, rules.VerticalFlow)...)
+	}
+	if rules.SemanticZone.Enabled {
+		diags = append(diags, checkSemanticZones(cv, rules.SemanticZone)...)
+	}
+	if rules.TextFit.Enabled {
+		diags = append(diags, checkTextFit(renderables, rules.TextFit)...)
+	}
+	if rules.MinSize.Enabled {
+		diags = append(diags, checkMinSize(renderables, rules.MinSize)...)
+	}
+	if rules.FullWidth.Enabled {
+		diags = append(diags, checkFullWidth(cv, rules.FullWidth)...)
+	}
+	if rules.Alignment.Enabled {
+		diags = append(diags, checkAlignment(renderables, rules.Alignment)...)
+	}
+	if rules.Spacing.Enabled {
+		grid := 0.0
+		if rules.Grid.Enabled {
+			grid = rules.Grid.Size
+		}
+		diags = append(diags, checkSpacing(renderables, rules.Spacing, grid)...)
+	}
+	if rules.Grid.Enabled {
+		diags = append(diags, checkGrid(renderables, rules.Grid)...)
 	}
 
-	return nil
+	return diags
 }
 
 // ──────────────────────────────────────────────
 // 📐 RULE 1: Collision Detection
 // ──────────────────────────────────────────────
 
-func checkCollisions(cells []mxCell) error {
+// checkCollisions flags overlapping elements. A child overlapping its own
+// container is expected and checked by the containment rule instead.
+func checkCollisions(cv *canvas, rule CollisionRule) []Diagnostic {
+	cells := cv.cells
+	var diags []Diagnostic
 	for i, a := range cells {
 		debugLog("🔍 [%s] (x=%.1f, y=%.1f, w=%.1f, h=%.1f)\n",
 			a.ID, a.Geometry.X, a.Geometry.Y, a.Geometry.Width, a.Geometry.Height)
 
 		for j := i + 1; j < len(cells); j++ {
 			b := cells[j]
-			if boxesOverlap(a, b) {
-				return fmt.Errorf("🚫 layout collision: %s overlaps with %s", a.ID, b.ID)
+			if a.ID != b.ID && boxesOverlap(a, b) && !cv.related(a, b) {
+				diags = append(diags, newDiagnostic(RuleCollision, rule.Severity,
+					fmt.Sprintf("layout collision: %s overlaps with %s", a.ID, b.ID), a, b))
 			}
 		}
 	}
-	fmt.Println("✅ No collisions detected")
-	return nil
+	if len(diags) == 0 {
+		debugLog("✅ No collisions detected\n")
+	}
+	return diags
 }
 
 func boxesOverlap(a, b mxCell) bool {
@@ -145,36 +392,45 @@ func boxesOverlap(a, b mxCell) bool {
 // 📏 RULE 2: Vertical Flow
 // ──────────────────────────────────────────────
 
-func checkVerticalFlow(cells []mxCell) error {
-	// Group cells by X bands (left/right columns)
-	const xTolerance = 20.0
-	var columns [][]mxCell
-
+// checkVerticalFlow checks that document order, the order elements are read,
+// tabbed through and exported in, runs top to bottom within each column of
+// siblings: an element written after another but placed entirely above it is
+// reported. Cells arrive in document order; columns are X bands of siblings.
+func checkVerticalFlow(cells []mxCell, rule VerticalFlowRule) []Diagnostic {
+	type column struct {
+		parent string
+		x      float64
+		cells  []mxCell
+	}
+	var columns []*column
 	for _, cell := range cells {
-		placed := false
-		for i := range columns {
-			if abs(cell.Geometry.X-columns[i][0].Geometry.X) < xTolerance {
-				columns[i] = append(columns[i], cell)
-				placed = true
+		var col *column
+		for _, c := range columns {
+			if c.parent == cell.Parent && abs(cell.Geometry.X-c.x) < rule.XTolerance {
+				col = c
 				break
 			}
 		}
-		if !placed {
-			columns = append(columns, []mxCell{cell})
+		if col == nil {
+			col = &column{parent: cell.Parent, x: cell.Geometry.X}
+			columns = append(columns, col)
 		}
+		col.cells = append(col.cells, cell)
 	}
 
+	var diags []Diagnostic
 	for _, col := range columns {
-		sort.SliceStable(col, func(i, j int) bool {
-			return col[i].Geometry.Y < col[j].Geometry.Y
-		})
-		for i := 1; i < len(col); i++ {
-			if col[i].Geometry.Y < col[i-1].Geometry.Y {
-				return fmt.Errorf("↕️ vertical flow error: element %s is above %s in X-group", col[i].ID, col[i-1].ID)
+		for i := 1; i < len(col.cells); i++ {
+			prev, curr := col.cells[i-1], col.cells[i]
+			if curr.Geometry.Y+curr.Geometry.Height <= prev.Geometry.Y {
+				diags = append(diags, newDiagnostic(RuleVerticalFlow, rule.Severity,
+					fmt.Sprintf("%s comes after %s in the document but sits above it, so reading order does not run top to bottom",
+						describe(curr), describe(prev)),
+					curr, prev))
 			}
 		}
 	}
-	return nil
+	return diags
 }
 
 func abs(f float64) float64 {
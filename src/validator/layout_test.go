@@ -0,0 +1,117 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// drawio wraps vertex cells, given as "id:parent:x,y,w,h", in an
+// mxGraphModel, in document order.
+func drawio(cells ...string) string {
+	var b strings.Builder
+	b.WriteString(`<mxGraphModel><root><mxCell id="0"/><mxCell id="1" parent="0"/>`)
+	for _, c := range cells {
+		parts := strings.SplitN(c, ":", 3)
+		var x, y, w, h float64
+		fmt.Sscanf(parts[2], "%g,%g,%g,%g", &x, &y, &w, &h)
+		fmt.Fprintf(&b, `<mxCell id="%s" value="%s" vertex="1" parent="%s"><mxGeometry x="%g" y="%g" width="%g" height="%g" as="geometry"/></mxCell>`,
+			parts[0], parts[0], parts[1], x, y, w, h)
+	}
+	b.WriteString(`</root></mxGraphModel>`)
+	return b.String()
+}
+
+func TestVerticalFlow(t *testing.T) {
+	tests := []struct {
+		name string
+		xml  string
+		want [][]string // cell IDs of each diagnostic
+	}{
+		{
+			name: "document order runs top to bottom",
+			xml:  drawio("a:1:10,0,100,40", "b:1:10,60,100,40", "c:1:10,120,100,40"),
+		},
+		{
+			name: "later element placed above an earlier one",
+			xml:  drawio("a:1:10,0,100,40", "c:1:10,120,100,40", "b:1:10,60,100,40"),
+			want: [][]string{{"b", "c"}},
+		},
+		{
+			name: "separate columns are independent",
+			xml:  drawio("left:1:0,200,100,40", "right:1:400,0,100,40"),
+		},
+		{
+			name: "overlapping rows are not an ordering problem",
+			xml:  drawio("a:1:10,20,100,40", "b:1:15,0,100,40"),
+		},
+		{
+			name: "only siblings share a column",
+			xml:  drawio("card:1:0,100,300,200", "title:card:0,0,300,40", "footer:1:0,0,300,40"),
+			want: [][]string{{"footer", "card"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags, err := ValidateWith(tt.xml, Rules{VerticalFlow: DefaultRules().VerticalFlow})
+			if err != nil {
+				t.Fatalf("ValidateWith: %v", err)
+			}
+			var got [][]string
+			for _, d := range diags {
+				got = append(got, d.CellIDs)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("diagnostics on %v, want %v: %v", got, tt.want, diags)
+			}
+		})
+	}
+}
+
+func TestDuplicateIDs(t *testing.T) {
+	defaults := DefaultRules()
+	rules := Rules{
+		Structure:    defaults.Structure,
+		Collision:    defaults.Collision,
+		Hidden:       defaults.Hidden,
+		Bounds:       defaults.Bounds,
+		VerticalFlow: defaults.VerticalFlow,
+	}
+	cell := func(id string, x, y float64) string {
+		return fmt.Sprintf(`<mxCell id="%s" value="%s" vertex="1" parent="1"><mxGeometry x="%g" y="%g" width="100" height="40" as="geometry"/></mxCell>`, id, id, x, y)
+	}
+
+	tests := []struct {
+		name  string
+		cells string
+		want  []string // "rule:cell IDs" of each diagnostic
+	}{
+		{
+			name:  "duplicate layer is checked once",
+			cells: `<mxCell id="1" parent="0"/>` + cell("a", 10, 10) + cell("b", 750, 100),
+			want:  []string{"structure:[1 1]", "bounds:[b]"},
+		},
+		{
+			name:  "a cell is never paired with its duplicate ID",
+			cells: cell("a", 10, 10) + cell("a", 10, 10),
+			want:  []string{"structure:[a a]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xml := `<mxGraphModel pageWidth="800" pageHeight="600"><root><mxCell id="0"/><mxCell id="1" parent="0"/>` + tt.cells + `</root></mxGraphModel>`
+			diags, err := ValidateWith(xml, rules)
+			if err != nil {
+				t.Fatalf("ValidateWith: %v", err)
+			}
+			var got []string
+			for _, d := range diags {
+				got = append(got, fmt.Sprintf("%s:%v", d.Rule, d.CellIDs))
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("diagnostics = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
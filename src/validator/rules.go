@@ -0,0 +1,336 @@
+// src/validator/rules.go
+package validator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rule holds the settings shared by every validator rule.
+type Rule struct {
+	Enabled  bool     `yaml:"enabled"`
+	Severity Severity `yaml:"severity"`
+}
+
+// CollisionRule flags overlapping elements.
+type CollisionRule struct {
+	Rule `yaml:",inline"`
+}
+
+// VerticalFlowRule checks that document order runs top to bottom within
+// columns.
+type VerticalFlowRule struct {
+	Rule       `yaml:",inline"`
+	XTolerance float64 `yaml:"x_tolerance"` // max x distance for two cells to share a column
+}
+
+// SemanticZoneRule checks that landmark components sit in their conventional
+// region. Bands are fractions of the page height (or width for sidebars and
+// FABs): the declared page size, or the content's extent if there is none.
+type SemanticZoneRule struct {
+	Rule          `yaml:",inline"`
+	NavMax        float64 `yaml:"nav_max"`        // navbar top edge must be above this
+	HeaderMax     float64 `yaml:"header_max"`     // header top edge must be above this
+	BreadcrumbMax float64 `yaml:"breadcrumb_max"` // breadcrumb top edge must be above this
+	ModalMin      float64 `yaml:"modal_min"`      // modal midpoint must be below this...
+	ModalMax      float64 `yaml:"modal_max"`      // ...and above this
+	FooterMin     float64 `yaml:"footer_min"`     // footer bottom edge must be below this
+	SidebarEdge   float64 `yaml:"sidebar_edge"`   // sidebar midpoint within this fraction of either side
+	FabMin        float64 `yaml:"fab_min"`        // FAB midpoint beyond this fraction on both axes
+}
+
+// StructureRule checks the mxGraphModel skeleton: root cell, default layer,
+// unique IDs, resolvable parents and no cycles.
+type StructureRule struct {
+	Rule `yaml:",inline"`
+}
+
+// EdgeRule checks that connectors attach to existing vertices.
+type EdgeRule struct {
+	Rule           `yaml:",inline"`
+	AllowSelfLoops bool `yaml:"allow_self_loops"`
+}
+
+// SizeRule flags elements with zero or negative width or height.
+type SizeRule struct {
+	Rule `yaml:",inline"`
+}
+
+// BoundsRule keeps elements on the page. PageWidth and PageHeight are used when
+// the mxGraphModel has no pageWidth/pageHeight attributes (0 disables the check);
+// the fixer only pulls elements back onto a page the model declares.
+type BoundsRule struct {
+	Rule          `yaml:",inline"`
+	PageWidth     float64 `yaml:"page_width"`
+	PageHeight    float64 `yaml:"page_height"`
+	EnforceHeight bool    `yaml:"enforce_height"` // pages usually scroll, so off by default
+}
+
+// ContainmentRule requires children to lie inside their parent container.
+type ContainmentRule struct {
+	Rule `yaml:",inline"`
+}
+
+// HiddenRule flags elements entirely covered by another element.
+type HiddenRule struct {
+	Rule `yaml:",inline"`
+}
+
+// AlignmentRule flags elements a few pixels off their column's left edge.
+type AlignmentRule struct {
+	Rule            `yaml:",inline"`
+	ColumnTolerance float64 `yaml:"column_tolerance"` // left edges closer than this share a column
+	AutoFix         bool    `yaml:"auto_fix"`
+}
+
+// SpacingRule checks that stacked components in a column have even gaps.
+type SpacingRule struct {
+	Rule            `yaml:",inline"`
+	Tolerance       float64 `yaml:"tolerance"`        // allowed deviation from the column's median gap
+	ColumnTolerance float64 `yaml:"column_tolerance"` // left edges closer than this share a column
+	AutoFix         bool    `yaml:"auto_fix"`
+}
+
+// GridRule requires element positions to be multiples of the grid size.
+type GridRule struct {
+	Rule    `yaml:",inline"`
+	Size    float64 `yaml:"size"` // px, e.g. 8 or 10
+	AutoFix bool    `yaml:"auto_fix"`
+}
+
+// FullWidthRule requires navbars and footers to span the layout width.
+type FullWidthRule struct {
+	Rule      `yaml:",inline"`
+	Tolerance float64 `yaml:"tolerance"` // px of slack at either edge
+	AutoFix   bool    `yaml:"auto_fix"`
+}
+
+// TextFitRule flags labels whose estimated rendered size overflows their
+// element. Text width is estimated as characters × font size × CharWidth.
+type TextFitRule struct {
+	Rule       `yaml:",inline"`
+	CharWidth  float64 `yaml:"char_width"`  // average glyph width as a fraction of the font size
+	LineHeight float64 `yaml:"line_height"` // line height as a multiple of the font size
+	Padding    float64 `yaml:"padding"`     // px of horizontal padding inside the element
+	AutoFix    bool    `yaml:"auto_fix"`
+}
+
+// MinSizeRule requires interactive components to be usable touch targets.
+type MinSizeRule struct {
+	Rule      `yaml:",inline"`
+	MinWidth  float64 `yaml:"min_width"`
+	MinHeight float64 `yaml:"min_height"`
+	AutoFix   bool    `yaml:"auto_fix"`
+}
+
+// FigmaSchemaRule checks Figma JSON against the builder prompt's node rules.
+type FigmaSchemaRule struct {
+	Rule `yaml:",inline"`
+}
+
+// Rules configures which validator rules run and how strict they are.
+// Views holds per-view-type overrides (keyed by ViewLayout.Type) that are
+// layered on top of the project-wide settings by ForView.
+type Rules struct {
+	Structure    StructureRule    `yaml:"structure"`
+	Collision    CollisionRule    `yaml:"collision"`
+	VerticalFlow VerticalFlowRule `yaml:"vertical-flow"`
+	SemanticZone SemanticZoneRule `yaml:"semantic-zone"`
+	Size         SizeRule         `yaml:"size"`
+	Bounds       BoundsRule       `yaml:"bounds"`
+	Containment  ContainmentRule  `yaml:"containment"`
+	Hidden       HiddenRule       `yaml:"hidden"`
+	Edge         EdgeRule         `yaml:"edge"`
+	Alignment    AlignmentRule    `yaml:"alignment"`
+	Spacing      SpacingRule      `yaml:"spacing"`
+	Grid         GridRule         `yaml:"grid"`
+	FullWidth    FullWidthRule    `yaml:"full-width"`
+	TextFit      TextFitRule      `yaml:"text-fit"`
+	MinSize      MinSizeRule      `yaml:"min-size"`
+	FigmaSchema  FigmaSchemaRule  `yaml:"figma-schema"`
+
+	Views map[string]yaml.Node `yaml:"views,omitempty"`
+}
+
+// DefaultRules returns the built-in rule set.
+func DefaultRules() Rules {
+	return Rules{
+		Structure: StructureRule{
+			Rule: Rule{Enabled: true, Severity: SeverityError},
+		},
+		Collision: CollisionRule{
+			Rule: Rule{Enabled: true, Severity: SeverityError},
+		},
+		VerticalFlow: VerticalFlowRule{
+			Rule:       Rule{Enabled: true, Severity: SeverityWarning},
+			XTolerance: 20.0,
+		},
+		SemanticZone: SemanticZoneRule{
+			Rule:          Rule{Enabled: true, Severity: SeverityError},
+			NavMax:        0.1,
+			HeaderMax:     0.2,
+			BreadcrumbMax: 0.25,
+			ModalMin:      0.3,
+			ModalMax:      0.7,
+			FooterMin:     0.9,
+			SidebarEdge:   0.25,
+			FabMin:        0.75,
+		},
+		Size: SizeRule{
+			Rule: Rule{Enabled: true, Severity: SeverityError},
+		},
+		Bounds: BoundsRule{
+			Rule:       Rule{Enabled: true, Severity: SeverityError},
+			PageWidth:  850,
+			PageHeight: 1100,
+		},
+		Containment: ContainmentRule{
+			Rule: Rule{Enabled: true, Severity: SeverityError},
+		},
+		Hidden: HiddenRule{
+			Rule: Rule{Enabled: true, Severity: SeverityWarning},
+		},
+		Edge: EdgeRule{
+			Rule: Rule{Enabled: true, Severity: SeverityError},
+		},
+		Alignment: AlignmentRule{
+			Rule:            Rule{Enabled: true, Severity: SeverityWarning},
+			ColumnTolerance: 8,
+		},
+		Spacing: SpacingRule{
+			Rule:            Rule{Enabled: true, Severity: SeverityWarning},
+			Tolerance:       2,
+			ColumnTolerance: 20,
+		},
+		// Off until a project opts into a grid; LLM layouts rarely follow one
+		Grid: GridRule{
+			Rule: Rule{Enabled: false, Severity: SeverityWarning},
+			Size: 10,
+		},
+		FullWidth: FullWidthRule{
+			Rule:      Rule{Enabled: true, Severity: SeverityWarning},
+			Tolerance: 2,
+		},
+		TextFit: TextFitRule{
+			Rule:       Rule{Enabled: true, Severity: SeverityWarning},
+			CharWidth:  0.6,
+			LineHeight: 1.2,
+			Padding:    16,
+		},
+		MinSize: MinSizeRule{
+			Rule:      Rule{Enabled: true, Severity: SeverityWarning},
+			MinWidth:  44,
+			MinHeight: 44,
+		},
+		FigmaSchema: FigmaSchemaRule{
+			Rule: Rule{Enabled: true, Severity: SeverityError},
+		},
+	}
+}
+
+// ForView returns the rules for a view type: project-wide settings with the
+// matching entry of Views decoded on top, so unset keys keep their values.
+// Unknown keys in the override are an error, as in the project-wide rules.
+func (r Rules) ForView(viewType string) (Rules, error) {
+	override, ok := r.Views[viewType]
+	if !ok {
+		return r, nil
+	}
+
+	// yaml.Node.Decode cannot reject unknown keys, so the override goes
+	// through a strict decoder
+	data, err := yaml.Marshal(&override)
+	if err != nil {
+		return r, fmt.Errorf("invalid rules for view type %q: %w", viewType, err)
+	}
+	merged := r
+	merged.Views = nil
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&merged); err != nil {
+		// Line numbers refer to the re-encoded override, not the file
+		var te *yaml.TypeError
+		if errors.As(err, &te) {
+			for i, e := range te.Errors {
+				if _, msg, ok := strings.Cut(e, ": "); ok && strings.HasPrefix(e, "line ") {
+					te.Errors[i] = msg
+				}
+			}
+		}
+		return r, fmt.Errorf("invalid rules for view type %q at line %d: %w", viewType, override.Line, err)
+	}
+	if merged.Views != nil {
+		return r, fmt.Errorf("invalid rules for view type %q at line %d: views cannot be nested", viewType, override.Line)
+	}
+	if err := merged.Check(); err != nil {
+		return r, fmt.Errorf("invalid rules for view type %q: %w", viewType, err)
+	}
+	merged.Views = r.Views
+	return merged, nil
+}
+
+// Check reports invalid severities or thresholds.
+func (r Rules) Check() error {
+	for name, rule := range map[string]Rule{
+		RuleCollision:    r.Collision.Rule,
+		RuleVerticalFlow: r.VerticalFlow.Rule,
+		RuleSemanticZone: r.SemanticZone.Rule,
+		RuleSize:         r.Size.Rule,
+		RuleBounds:       r.Bounds.Rule,
+		RuleContainment:  r.Containment.Rule,
+		RuleHidden:       r.Hidden.Rule,
+		RuleStructure:    r.Structure.Rule,
+		RuleEdge:         r.Edge.Rule,
+		RuleAlignment:    r.Alignment.Rule,
+		RuleSpacing:      r.Spacing.Rule,
+		RuleGrid:         r.Grid.Rule,
+		RuleFullWidth:    r.FullWidth.Rule,
+		RuleTextFit:      r.TextFit.Rule,
+		RuleMinSize:      r.MinSize.Rule,
+		RuleFigmaSchema:  r.FigmaSchema.Rule,
+	} {
+		if rule.Severity != SeverityError && rule.Severity != SeverityWarning {
+			return fmt.Errorf("rule %s: severity must be %q or %q, got %q",
+				name, SeverityError, SeverityWarning, rule.Severity)
+		}
+	}
+
+	if r.VerticalFlow.XTolerance < 0 {
+		return fmt.Errorf("rule %s: x_tolerance must not be negative", RuleVerticalFlow)
+	}
+
+	for name, f := range map[string]float64{
+		RuleAlignment: r.Alignment.ColumnTolerance,
+		RuleSpacing:   min(r.Spacing.Tolerance, r.Spacing.ColumnTolerance),
+		RuleFullWidth: r.FullWidth.Tolerance,
+		RuleTextFit:   min(r.TextFit.CharWidth, r.TextFit.LineHeight, r.TextFit.Padding),
+		RuleMinSize:   min(r.MinSize.MinWidth, r.MinSize.MinHeight),
+	} {
+		if f < 0 {
+			return fmt.Errorf("rule %s: sizes and tolerances must not be negative", name)
+		}
+	}
+
+	if r.Grid.Size < 0 {
+		return fmt.Errorf("rule %s: size must not be negative", RuleGrid)
+	}
+
+	if r.Bounds.PageWidth < 0 || r.Bounds.PageHeight < 0 {
+		return fmt.Errorf("rule %s: page size must not be negative", RuleBounds)
+	}
+
+	z := r.SemanticZone
+	for _, f := range []float64{z.NavMax, z.HeaderMax, z.BreadcrumbMax, z.ModalMin, z.ModalMax, z.FooterMin, z.SidebarEdge, z.FabMin} {
+		if f < 0 || f > 1 {
+			return fmt.Errorf("rule %s: zone bands must be between 0 and 1", RuleSemanticZone)
+		}
+	}
+	if z.ModalMin > z.ModalMax {
+		return fmt.Errorf("rule %s: modal_min must not exceed modal_max", RuleSemanticZone)
+	}
+	return nil
+}
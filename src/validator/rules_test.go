@@ -0,0 +1,138 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// withViews returns the default rules with the given rules.views YAML.
+func withViews(t *testing.T, views string) Rules {
+	t.Helper()
+	rules := DefaultRules()
+	if err := yaml.Unmarshal([]byte(views), &rules.Views); err != nil {
+		t.Fatalf("bad test YAML: %v", err)
+	}
+	return rules
+}
+
+func TestForView(t *testing.T) {
+	views := `
+modal:
+  semantic-zone:
+    modal_min: 0.1
+  grid:
+    enabled: true
+dashboard:
+  vertical-flow:
+    severity: error
+`
+	tests := []struct {
+		name  string
+		view  string
+		check func(t *testing.T, r Rules)
+	}{
+		{
+			name: "unlisted view type keeps the project rules",
+			view: "primary",
+			check: func(t *testing.T, r Rules) {
+				if r.SemanticZone.ModalMin != 0.3 || r.Grid.Enabled {
+					t.Errorf("rules changed: %+v %+v", r.SemanticZone, r.Grid)
+				}
+			},
+		},
+		{
+			name: "set keys override, unset keys keep their values",
+			view: "modal",
+			check: func(t *testing.T, r Rules) {
+				z := r.SemanticZone
+				if z.ModalMin != 0.1 || z.ModalMax != 0.7 || !z.Enabled || z.Severity != SeverityError {
+					t.Errorf("semantic-zone = %+v", z)
+				}
+				if !r.Grid.Enabled || r.Grid.Size != 10 || r.Grid.Severity != SeverityWarning {
+					t.Errorf("grid = %+v", r.Grid)
+				}
+			},
+		},
+		{
+			name: "overrides of one view type do not leak into another",
+			view: "dashboard",
+			check: func(t *testing.T, r Rules) {
+				if r.VerticalFlow.Severity != SeverityError || r.VerticalFlow.XTolerance != 20 {
+					t.Errorf("vertical-flow = %+v", r.VerticalFlow)
+				}
+				if r.SemanticZone.ModalMin != 0.3 {
+					t.Errorf("modal_min = %v, want the project value", r.SemanticZone.ModalMin)
+				}
+			},
+		},
+	}
+	rules := withViews(t, views)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rules.ForView(tt.view)
+			if err != nil {
+				t.Fatalf("ForView: %v", err)
+			}
+			tt.check(t, got)
+			if len(got.Views) != 2 {
+				t.Errorf("Views not kept for later lookups: %v", got.Views)
+			}
+		})
+	}
+}
+
+func TestForViewRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		views string
+		want  string
+	}{
+		{"unknown rule", "modal:\n  colision:\n    enabled: false\n", "field colision not found"},
+		{"unknown key", "modal:\n  grid:\n    enabeld: false\n", "field enabeld not found"},
+		{"invalid severity", "modal:\n  grid:\n    severity: fatal\n", `severity must be "error" or "warning"`},
+		{"zone band out of range", "modal:\n  semantic-zone:\n    modal_max: 1.5\n", "zone bands must be between 0 and 1"},
+		{"nested views", "modal:\n  views:\n    card: {}\n", "views cannot be nested"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := withViews(t, tt.views).ForView("modal")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(r *Rules)
+		want   string // empty if the rules are valid
+	}{
+		{name: "defaults", modify: func(r *Rules) {}},
+		{name: "empty severity", modify: func(r *Rules) { r.Hidden.Severity = "" }, want: "rule hidden: severity"},
+		{name: "negative x tolerance", modify: func(r *Rules) { r.VerticalFlow.XTolerance = -1 }, want: "x_tolerance must not be negative"},
+		{name: "negative spacing tolerance", modify: func(r *Rules) { r.Spacing.Tolerance = -2 }, want: "rule spacing: sizes and tolerances"},
+		{name: "negative min size", modify: func(r *Rules) { r.MinSize.MinHeight = -44 }, want: "rule min-size"},
+		{name: "negative grid", modify: func(r *Rules) { r.Grid.Size = -8 }, want: "rule grid: size must not be negative"},
+		{name: "negative page", modify: func(r *Rules) { r.Bounds.PageWidth = -1 }, want: "page size must not be negative"},
+		{name: "zone band above 1", modify: func(r *Rules) { r.SemanticZone.FooterMin = 1.1 }, want: "zone bands"},
+		{name: "modal band inverted", modify: func(r *Rules) { r.SemanticZone.ModalMin = 0.8 }, want: "modal_min must not exceed modal_max"},
+		{name: "zero thresholds are allowed", modify: func(r *Rules) { r.Grid.Size, r.Bounds.PageWidth = 0, 0 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := DefaultRules()
+			tt.modify(&rules)
+			err := rules.Check()
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("Check: %v", err)
+			case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
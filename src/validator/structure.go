@@ -0,0 +1,344 @@
+// src/validator/structure.go
+package validator
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/beevik/etree"
+)
+
+// ──────────────────────────────────────────────
+// 🧱 RULE: Structural Integrity
+// ──────────────────────────────────────────────
+
+// checkStructure verifies the mxGraphModel skeleton before any geometry rule
+// runs: a root cell, a layer beneath it, unique IDs, resolvable parents, no
+// parent cycles and no nested mxCells.
+func checkStructure(model mxGraphModel, rule StructureRule) []Diagnostic {
+	var diags []Diagnostic
+	report := func(msg string, cells ...mxCell) {
+		diags = append(diags, newDiagnostic(RuleStructure, rule.Severity, msg, cells...))
+	}
+
+	if len(model.Cells) == 0 {
+		report("model has no <root> cells")
+		return diags
+	}
+
+	byID := make(map[string]mxCell)
+	for _, c := range model.Cells {
+		switch {
+		case c.ID == "":
+			report(fmt.Sprintf("cell %s has no id", describe(c)), c)
+		case byID[c.ID].ID != "":
+			report(fmt.Sprintf("duplicate cell id %q", c.ID), byID[c.ID], c)
+		default:
+			byID[c.ID] = c
+		}
+		for _, nested := range c.Nested {
+			report(fmt.Sprintf("%s is nested inside %s instead of being a child of <root>",
+				describe(nested), describe(c)), nested, c)
+		}
+	}
+
+	rootCell, hasRoot := byID["0"]
+	switch {
+	case !hasRoot:
+		report(`missing root cell <mxCell id="0"/>`)
+	case rootCell.Parent != "":
+		report(fmt.Sprintf(`root cell "0" must not have a parent (has %q)`, rootCell.Parent), rootCell)
+	}
+
+	layer, hasLayer := byID["1"]
+	switch {
+	case !hasLayer:
+		report(`missing default layer <mxCell id="1" parent="0"/>`)
+	case layer.Parent != "0":
+		report(fmt.Sprintf(`default layer "1" must have parent="0" (has %q)`, layer.Parent), layer)
+	}
+
+	for _, c := range model.Cells {
+		if c.ID == "0" || c.ID == "1" {
+			continue
+		}
+		switch {
+		case c.Parent == "":
+			report(fmt.Sprintf("%s has no parent", describe(c)), c)
+		case c.Parent == c.ID:
+			report(fmt.Sprintf("%s is its own parent", describe(c)), c)
+		case byID[c.Parent].ID == "":
+			report(fmt.Sprintf("%s references missing parent %q", describe(c), c.Parent), c)
+		case c.Parent == "0" && (c.Vertex == "1" || c.Edge == "1"):
+			report(fmt.Sprintf("%s is attached to the root cell instead of a layer", describe(c)), c)
+		}
+	}
+
+	for _, cycle := range findCycles(model.Cells, byID) {
+		report(fmt.Sprintf("parent cycle: %s", cycleString(cycle)), cycle...)
+	}
+
+	return diags
+}
+
+// findCycles returns every parent cycle, each listed once in discovery order.
+func findCycles(cells []mxCell, byID map[string]mxCell) [][]mxCell {
+	state := make(map[string]int) // 0 = unvisited, 1 = on current path, 2 = done
+	var cycles [][]mxCell
+
+	for _, start := range cells {
+		if start.ID == "" || state[start.ID] != 0 {
+			continue
+		}
+
+		var path []mxCell
+		c, ok := start, true
+		for ok && state[c.ID] == 0 {
+			state[c.ID] = 1
+			path = append(path, c)
+			if c.Parent == c.ID {
+				break // self-parenting is reported separately
+			}
+			c, ok = byID[c.Parent]
+		}
+
+		if ok && state[c.ID] == 1 && c.Parent != c.ID {
+			for i, p := range path {
+				if p.ID == c.ID {
+					cycles = append(cycles, append([]mxCell(nil), path[i:]...))
+					break
+				}
+			}
+		}
+		for _, p := range path {
+			state[p.ID] = 2
+		}
+	}
+	return cycles
+}
+
+func cycleString(cycle []mxCell) string {
+	s := ""
+	for _, c := range cycle {
+		s += c.ID + " → "
+	}
+	return s + cycle[0].ID
+}
+
+// ──────────────────────────────────────────────
+// 🔧 Structural Repair
+// ──────────────────────────────────────────────
+
+// Repair deterministically fixes the structural mistakes LLMs commonly make in
+// a single <mxGraphModel> and returns the repaired XML with a description of
+// every change. The same input always yields the same output.
+func Repair(raw string) (string, []string, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromString(raw); err != nil {
+		return "", nil, fmt.Errorf("failed to parse XML for repair: %w", err)
+	}
+
+	model := doc.Root()
+	if model == nil || model.Tag != "mxGraphModel" {
+		return "", nil, fmt.Errorf("no <mxGraphModel> root element")
+	}
+
+	fixes := repairModel(model)
+
+	doc.Indent(2)
+	out, err := doc.WriteToString()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to serialize repaired XML: %w", err)
+	}
+	return out, fixes, nil
+}
+
+// repairModel applies structural fixes to an <mxGraphModel> element in place.
+func repairModel(model *etree.Element) []string {
+	var fixes []string
+	fix := func(format string, args ...interface{}) {
+		fixes = append(fixes, fmt.Sprintf(format, args...))
+	}
+
+	// Cells placed directly under <mxGraphModel> belong inside <root>
+	root := model.SelectElement("root")
+	if root == nil {
+		root = model.CreateElement("root")
+		fix("added missing <root> element")
+	}
+	for _, stray := range model.SelectElements("mxCell") {
+		model.RemoveChild(stray)
+		root.AddChild(stray)
+		fix("moved cell %q under <root>", stray.SelectAttrValue("id", ""))
+	}
+
+	// Lift nested mxCells to <root>. Builder output uses absolute coordinates,
+	// so a nested cell without a parent is attached to the layer, not its wrapper
+	for i := 0; i < len(root.ChildElements()); i++ {
+		cell := root.ChildElements()[i]
+		if cell.Tag != "mxCell" {
+			continue
+		}
+		for _, nested := range cell.SelectElements("mxCell") {
+			cell.RemoveChild(nested)
+			root.InsertChildAt(cell.Index()+1, nested)
+			fix("lifted nested cell %q out of %q", nested.SelectAttrValue("id", ""), cell.SelectAttrValue("id", ""))
+		}
+	}
+
+	cells := cellElements(root)
+
+	// Missing and duplicate IDs get fresh numeric IDs above the current maximum.
+	// A parent, source or target naming a duplicated ID means the nearest
+	// preceding cell with that ID, as LLMs write a container just before its
+	// children; references before the first such cell keep pointing at it
+	next := 2
+	count := make(map[string]int)
+	for _, el := range cells {
+		id := el.SelectAttrValue("id", "")
+		count[id]++
+		if n, err := strconv.Atoi(id); err == nil && n >= next {
+			next = n + 1
+		}
+	}
+	seen := make(map[string]bool)
+	latest := make(map[string]string) // duplicated ID → ID of its nearest definition so far
+	for _, el := range cells {
+		id, final := el.SelectAttrValue("id", ""), ""
+		switch {
+		case id != "" && !seen[id]:
+			final = id
+		case id == "":
+			final = strconv.Itoa(next)
+			next++
+			fix("assigned id %q to a cell without one", final)
+		default:
+			final = strconv.Itoa(next)
+			next++
+			fix("renamed duplicate id %q to %q; references to %q after it resolve to the nearest preceding cell with that id", id, final, id)
+		}
+		el.CreateAttr("id", final)
+		seen[final] = true
+
+		// The cell's own references are resolved before it defines its ID
+		cell := innerCell(el)
+		for _, attr := range []string{"parent", "source", "target"} {
+			ref := cell.SelectAttrValue(attr, "")
+			if to, ok := latest[ref]; ok && to != ref && count[ref] > 1 {
+				cell.CreateAttr(attr, to)
+				fix("pointed %s of cell %q at %q, the nearest preceding cell with id %q", attr, final, to, ref)
+			}
+		}
+		if id != "" {
+			latest[id] = final
+		}
+	}
+
+	// Root cell "0" and default layer "1" come first
+	if !seen["0"] {
+		zero := etree.NewElement("mxCell")
+		zero.CreateAttr("id", "0")
+		root.InsertChildAt(0, zero)
+		fix(`added root cell <mxCell id="0"/>`)
+	}
+	if !seen["1"] {
+		one := etree.NewElement("mxCell")
+		one.CreateAttr("id", "1")
+		one.CreateAttr("parent", "0")
+		root.InsertChildAt(1, one)
+		fix(`added default layer <mxCell id="1" parent="0"/>`)
+	}
+
+	cells = cellElements(root)
+	byID := make(map[string]*etree.Element)
+	for _, el := range cells {
+		byID[el.SelectAttrValue("id", "")] = el
+	}
+
+	for _, el := range cells {
+		id := el.SelectAttrValue("id", "")
+		cell := innerCell(el)
+		parent := cell.SelectAttrValue("parent", "")
+		content := cell.SelectAttrValue("vertex", "") == "1" || cell.SelectAttrValue("edge", "") == "1"
+
+		switch {
+		case id == "0":
+			if parent != "" {
+				cell.RemoveAttr("parent")
+				fix(`removed parent %q from root cell "0"`, parent)
+			}
+		case id == "1":
+			if parent != "0" {
+				cell.CreateAttr("parent", "0")
+				fix(`set parent="0" on default layer "1"`)
+			}
+		case parent == "":
+			cell.CreateAttr("parent", "1")
+			fix("attached orphan cell %q to layer \"1\"", id)
+		case parent == id:
+			cell.CreateAttr("parent", "1")
+			fix("re-parented self-parented cell %q to layer \"1\"", id)
+		case byID[parent] == nil:
+			cell.CreateAttr("parent", "1")
+			fix("re-parented cell %q from missing parent %q to layer \"1\"", id, parent)
+		case parent == "0" && content:
+			cell.CreateAttr("parent", "1")
+			fix("moved cell %q from the root cell to layer \"1\"", id)
+		}
+	}
+
+	// Break parent cycles by attaching the first revisited cell to layer "1"
+	for _, el := range cells {
+		visited := map[string]bool{}
+		for c := el; c != nil; c = byID[innerCell(c).SelectAttrValue("parent", "")] {
+			id := c.SelectAttrValue("id", "")
+			if visited[id] {
+				innerCell(c).CreateAttr("parent", "1")
+				fix("broke parent cycle at cell %q by attaching it to layer \"1\"", id)
+				break
+			}
+			visited[id] = true
+		}
+	}
+
+	// Every vertex needs a geometry
+	for _, el := range cells {
+		cell := innerCell(el)
+		if cell.SelectAttrValue("vertex", "") == "1" && cell.SelectElement("mxGeometry") == nil {
+			geo := cell.CreateElement("mxGeometry")
+			geo.CreateAttr("x", "0")
+			geo.CreateAttr("y", "0")
+			geo.CreateAttr("width", "100")
+			geo.CreateAttr("height", "50")
+			geo.CreateAttr("as", "geometry")
+			fix("added default geometry to cell %q", el.SelectAttrValue("id", ""))
+		}
+	}
+
+	return fixes
+}
+
+// cellElements returns the cell-like children of <root>: plain mxCells and the
+// <object>/<UserObject> wrappers diagrams.net uses for cells with properties.
+func cellElements(root *etree.Element) []*etree.Element {
+	var cells []*etree.Element
+	for _, el := range root.ChildElements() {
+		switch el.Tag {
+		case "mxCell", "object", "UserObject":
+			cells = append(cells, el)
+		}
+	}
+	return cells
+}
+
+// innerCell returns the element carrying parent/vertex/geometry: the element
+// itself, or the mxCell inside a wrapper (created if missing).
+func innerCell(el *etree.Element) *etree.Element {
+	if el.Tag == "mxCell" {
+		return el
+	}
+	if cell := el.SelectElement("mxCell"); cell != nil {
+		return cell
+	}
+	return el.CreateElement("mxCell")
+}
@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/beevik/etree"
+)
+
+// parents reads back each cell's parent, source and target as
+// "parent[,source>target]", by the cell's position in document order.
+func parents(t *testing.T, xml string) []string {
+	t.Helper()
+	doc := etree.NewDocument()
+	if err := doc.ReadFromString(xml); err != nil {
+		t.Fatalf("repaired XML does not parse: %v", err)
+	}
+	var out []string
+	for _, el := range cellElements(doc.FindElement("//root")) {
+		cell := innerCell(el)
+		s := el.SelectAttrValue("id", "") + "^" + cell.SelectAttrValue("parent", "")
+		if src, dst := cell.SelectAttrValue("source", ""), cell.SelectAttrValue("target", ""); src != "" || dst != "" {
+			s += "," + src + ">" + dst
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func TestRepair(t *testing.T) {
+	const geo = `<mxGeometry x="0" y="0" width="10" height="10" as="geometry"/>`
+	tests := []struct {
+		name  string
+		xml   string
+		want  []string // id^parent[,source>target] of each cell
+		fixes []string // substrings of the fixes, in order
+	}{
+		{
+			name: "sound model is left alone",
+			xml:  `<mxGraphModel><root><mxCell id="0"/><mxCell id="1" parent="0"/><mxCell id="2" vertex="1" parent="1">` + geo + `</mxCell></root></mxGraphModel>`,
+			want: []string{"0^", "1^0", "2^1"},
+		},
+		{
+			name:  "missing root cell and layer are added",
+			xml:   `<mxGraphModel><root><mxCell id="2" vertex="1" parent="1">` + geo + `</mxCell></root></mxGraphModel>`,
+			want:  []string{"0^", "1^0", "2^1"},
+			fixes: []string{`added root cell`, `added default layer`},
+		},
+		{
+			// The second "2" is a container; the cell after it means that
+			// container, the edge before it the first "2"
+			name: "duplicate ids keep references to the nearest preceding cell",
+			xml: `<mxGraphModel><root><mxCell id="0"/><mxCell id="1" parent="0"/>` +
+				`<mxCell id="2" vertex="1" parent="1">` + geo + `</mxCell>` +
+				`<mxCell id="3" edge="1" parent="1" source="2" target="2"><mxGeometry relative="1" as="geometry"/></mxCell>` +
+				`<mxCell id="2" vertex="1" parent="1">` + geo + `</mxCell>` +
+				`<mxCell id="4" vertex="1" parent="2">` + geo + `</mxCell>` +
+				`</root></mxGraphModel>`,
+			want: []string{"0^", "1^0", "2^1", "3^1,2>2", "5^1", "4^5"},
+			fixes: []string{
+				`renamed duplicate id "2" to "5"`,
+				`pointed parent of cell "4" at "5", the nearest preceding cell with id "2"`,
+			},
+		},
+		{
+			name:  "dangling and self parents go to the layer",
+			xml:   `<mxGraphModel><root><mxCell id="0"/><mxCell id="1" parent="0"/><mxCell id="2" vertex="1" parent="9">` + geo + `</mxCell><mxCell id="3" vertex="1" parent="3">` + geo + `</mxCell></root></mxGraphModel>`,
+			want:  []string{"0^", "1^0", "2^1", "3^1"},
+			fixes: []string{`from missing parent "9"`, `self-parented cell "3"`},
+		},
+		{
+			name:  "parent cycles are broken",
+			xml:   `<mxGraphModel><root><mxCell id="0"/><mxCell id="1" parent="0"/><mxCell id="2" vertex="1" parent="3">` + geo + `</mxCell><mxCell id="3" vertex="1" parent="2">` + geo + `</mxCell></root></mxGraphModel>`,
+			want:  []string{"0^", "1^0", "2^1", "3^2"},
+			fixes: []string{`broke parent cycle at cell "2"`},
+		},
+		{
+			name:  "nested cells are lifted and given geometry",
+			xml:   `<mxGraphModel><root><mxCell id="0"/><mxCell id="1" parent="0"/><mxCell id="2" vertex="1" parent="1">` + geo + `<mxCell id="3" vertex="1" parent="1"/></mxCell></root></mxGraphModel>`,
+			want:  []string{"0^", "1^0", "2^1", "3^1"},
+			fixes: []string{`lifted nested cell "3" out of "2"`, `added default geometry to cell "3"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, fixes, err := Repair(tt.xml)
+			if err != nil {
+				t.Fatalf("Repair: %v", err)
+			}
+			if got := parents(t, out); strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("cells = %v, want %v", got, tt.want)
+			}
+			if len(fixes) != len(tt.fixes) {
+				t.Fatalf("fixes = %q, want %d", fixes, len(tt.fixes))
+			}
+			for i, want := range tt.fixes {
+				if !strings.Contains(fixes[i], want) {
+					t.Errorf("fix %d = %q, want it to mention %q", i, fixes[i], want)
+				}
+			}
+
+			again, _, err := Repair(tt.xml)
+			if err != nil || again != out {
+				t.Errorf("repair is not deterministic")
+			}
+			if _, more, err := Repair(out); err != nil || len(more) > 0 {
+				t.Errorf("repairing the repaired model changed it again: %q, %v", more, err)
+			}
+		})
+	}
+}
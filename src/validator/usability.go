@@ -0,0 +1,128 @@
+// src/validator/usability.go
+package validator
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	"holoplan-cli/src/ir"
+	"holoplan-cli/src/taxonomy"
+)
+
+// defaultFontSize is Draw.io's font size when a style sets none.
+const defaultFontSize = 12
+
+// labelLines turns a cell value into its visible lines of text, honouring the
+// line breaks of html=1 labels.
+func labelLines(value string) []string {
+	return ir.Lines(value)
+}
+
+// fontSize reads the fontSize style entry, falling back to Draw.io's default.
+func fontSize(styles map[string]string) float64 {
+	if f, err := strconv.ParseFloat(styles["fontSize"], 64); err == nil && f > 0 {
+		return f
+	}
+	return defaultFontSize
+}
+
+// ──────────────────────────────────────────────
+// 🔤 RULE: Text Fit
+// ──────────────────────────────────────────────
+
+// checkTextFit estimates each label's rendered size from its character count
+// and font size and flags labels that overflow their element. Wrapped labels
+// (whiteSpace=wrap) need enough height for every wrapped line; others need
+// enough width for their longest line. Auto-sized cells and hidden labels
+// (noLabel=1) are skipped.
+func checkTextFit(cells []mxCell, rule TextFitRule) []Diagnostic {
+	var diags []Diagnostic
+	for _, c := range cells {
+		lines := labelLines(c.Value)
+		if len(lines) == 0 {
+			continue
+		}
+		styles := taxonomy.ParseStyle(c.Style)
+		if styles["autosize"] == "1" || styles["noLabel"] == "1" {
+			continue
+		}
+
+		size := fontSize(styles)
+		charWidth := size * rule.CharWidth
+		lineHeight := size * rule.LineHeight
+		g := c.Geometry
+
+		longest := 0.0
+		for _, line := range lines {
+			longest = max(longest, float64(utf8.RuneCountInString(line))*charWidth)
+		}
+
+		suggestion := Rect{X: g.X, Y: g.Y, Width: g.Width, Height: g.Height}
+		var problem string
+
+		if styles["whiteSpace"] == "wrap" {
+			room := g.Width - rule.Padding
+			if room <= charWidth {
+				continue // the size rule covers collapsed elements
+			}
+			wrapped := 0.0
+			for _, line := range lines {
+				wrapped += math.Ceil(float64(utf8.RuneCountInString(line)) * charWidth / room)
+			}
+			need := math.Ceil(wrapped*lineHeight + rule.Padding)
+			if need <= g.Height {
+				continue
+			}
+			suggestion.Height = need
+			problem = fmt.Sprintf("label of %s needs about %gpx of height but the element is %gpx tall",
+				describe(c), need, g.Height)
+		} else {
+			need := math.Ceil(longest + rule.Padding)
+			if need <= g.Width {
+				continue
+			}
+			suggestion.Width = need
+			if h := math.Ceil(float64(len(lines))*lineHeight + rule.Padding/2); h > g.Height {
+				suggestion.Height = h
+			}
+			problem = fmt.Sprintf("label of %s needs about %gpx of width but the element is %gpx wide",
+				describe(c), need, g.Width)
+		}
+
+		d := newDiagnostic(RuleTextFit, rule.Severity, problem, c)
+		d.Suggestion = &suggestion
+		diags = append(diags, d)
+	}
+	return diags
+}
+
+// ──────────────────────────────────────────────
+// 👆 RULE: Minimum Touch Target
+// ──────────────────────────────────────────────
+
+// checkMinSize flags interactive components smaller than a usable touch target.
+func checkMinSize(cells []mxCell, rule MinSizeRule) []Diagnostic {
+	var diags []Diagnostic
+	for _, c := range cells {
+		kind := taxonomy.Classify(c.Value, c.Style)
+		if !kind.Interactive() {
+			continue
+		}
+		g := c.Geometry
+		if g.Width <= 0 || g.Height <= 0 {
+			continue // the size rule covers collapsed elements
+		}
+		if g.Width >= rule.MinWidth && g.Height >= rule.MinHeight {
+			continue
+		}
+
+		d := newDiagnostic(RuleMinSize, rule.Severity,
+			fmt.Sprintf("%s %s is %gx%g, smaller than the %gx%g minimum touch target",
+				kind, describe(c), g.Width, g.Height, rule.MinWidth, rule.MinHeight), c)
+		d.Suggestion = &Rect{X: g.X, Y: g.Y, Width: max(g.Width, rule.MinWidth), Height: max(g.Height, rule.MinHeight)}
+		diags = append(diags, d)
+	}
+	return diags
+}
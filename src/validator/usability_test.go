@@ -0,0 +1,53 @@
+package validator
+
+import (
+	"fmt"
+	"html"
+	"testing"
+)
+
+func TestUsabilityRules(t *testing.T) {
+	cell := func(value, style string, w, h float64) string {
+		return fmt.Sprintf(`<mxGraphModel><root><mxCell id="0"/><mxCell id="1" parent="0"/><mxCell id="c" value="%s" style="%s" vertex="1" parent="1"><mxGeometry x="0" y="0" width="%g" height="%g" as="geometry"/></mxCell></root></mxGraphModel>`,
+			html.EscapeString(value), style, w, h)
+	}
+	defaults := DefaultRules()
+	textFit := Rules{TextFit: defaults.TextFit}
+	minSize := Rules{MinSize: defaults.MinSize}
+
+	tests := []struct {
+		name  string
+		xml   string
+		rules Rules
+		want  string // suggested "WxH", empty for no diagnostic
+	}{
+		{name: "short label fits", xml: cell("Save", "", 100, 40), rules: textFit},
+		{name: "long label needs width", xml: cell("Create new account", "", 100, 40), rules: textFit, want: "146x40"},
+		{name: "wrapped label needs height", xml: cell("Create new account now please", "whiteSpace=wrap;", 100, 40), rules: textFit, want: "100x60"},
+		{name: "larger font needs more room", xml: cell("Save", "fontSize=24;", 60, 40), rules: textFit, want: "74x40"},
+		{name: "html line breaks split the label", xml: cell("Line one<br>Line two", "html=1;", 80, 40), rules: textFit},
+		{name: "auto-sized cells are skipped", xml: cell("Create new account", "autosize=1;", 100, 40), rules: textFit},
+		{name: "hidden labels are skipped", xml: cell("Create new account", "noLabel=1;", 100, 40), rules: textFit},
+		{name: "small button", xml: cell("Submit", "", 80, 30), rules: minSize, want: "80x44"},
+		{name: "small text is not a touch target", xml: cell("Title", "", 20, 20), rules: minSize},
+		{name: "collapsed button is left to the size rule", xml: cell("Submit", "", 0, 30), rules: minSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags, err := ValidateWith(tt.xml, tt.rules)
+			if err != nil {
+				t.Fatalf("ValidateWith: %v", err)
+			}
+			got := ""
+			if len(diags) > 1 {
+				t.Fatalf("diagnostics = %v, want at most one", diags)
+			}
+			if len(diags) == 1 {
+				got = fmt.Sprintf("%gx%g", diags[0].Suggestion.Width, diags[0].Suggestion.Height)
+			}
+			if got != tt.want {
+				t.Errorf("suggestion = %q, want %q: %v", got, tt.want, diags)
+			}
+		})
+	}
+}
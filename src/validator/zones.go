@@ -3,46 +3,86 @@ package validator
 
 import (
 	"fmt"
-	"strings"
+
+	"holoplan-cli/src/taxonomy"
 )
 
 // ──────────────────────────────────────────────
 // RULE: Semantic Zone Conformance
 // ──────────────────────────────────────────────
 
-func checkSemanticZones(cells []mxCell) error {
+// checkSemanticZones classifies each cell from its label and style and checks
+// that landmark components sit in their conventional region of the page. The
+// page is the declared pageWidth/pageHeight, grown to the content if it
+// spills over; without a declared size it is the content's extent. Top
+// landmarks are placed by their top edge and footers by their bottom edge, so
+// a tall navbar or footer on a short page still counts as in place.
+func checkSemanticZones(cv *canvas, rule SemanticZoneRule) []Diagnostic {
+	cells := cv.cells
 	if len(cells) == 0 {
 		return nil
 	}
 
-	var maxY float64
+	maxX, maxY := cv.pageWidth, cv.pageHeight
 	for _, c := range cells {
-		yBottom := c.Geometry.Y + c.Geometry.Height
-		if yBottom > maxY {
-			maxY = yBottom
-		}
+		maxX = max(maxX, c.Geometry.X+c.Geometry.Width)
+		maxY = max(maxY, c.Geometry.Y+c.Geometry.Height)
 	}
 
+	var diags []Diagnostic
 	for _, c := range cells {
+		top, bottom := c.Geometry.Y, c.Geometry.Y+c.Geometry.Height
+		xMid := c.Geometry.X + c.Geometry.Width/2
 		yMid := c.Geometry.Y + c.Geometry.Height/2
-		id := strings.ToLower(c.ID)
+		name := describe(c)
 
-		switch {
-		case strings.Contains(id, "nav"):
-			if yMid > 0.1*maxY {
-				return fmt.Errorf("🧭 navbar (%s) should be near the top", c.ID)
+		var problem string
+		switch taxonomy.Classify(c.Value, c.Style) {
+		case taxonomy.Navbar:
+			if top > rule.NavMax*maxY {
+				problem = fmt.Sprintf("navbar %s should be near the top", name)
+			}
+		case taxonomy.Header:
+			if top > rule.HeaderMax*maxY {
+				problem = fmt.Sprintf("header %s should be near the top", name)
+			}
+		case taxonomy.Breadcrumb:
+			if top > rule.BreadcrumbMax*maxY {
+				problem = fmt.Sprintf("breadcrumb %s should sit just below the navbar", name)
+			}
+		case taxonomy.Modal:
+			if yMid < rule.ModalMin*maxY || yMid > rule.ModalMax*maxY {
+				problem = fmt.Sprintf("modal %s should be centered", name)
+			}
+		case taxonomy.Footer:
+			if bottom < rule.FooterMin*maxY {
+				problem = fmt.Sprintf("footer %s should be at the bottom", name)
 			}
-		case strings.Contains(id, "modal"):
-			if yMid < 0.3*maxY || yMid > 0.7*maxY {
-				return fmt.Errorf("🧭 modal (%s) should be centered", c.ID)
+		case taxonomy.Sidebar:
+			if xMid > rule.SidebarEdge*maxX && xMid < (1-rule.SidebarEdge)*maxX {
+				problem = fmt.Sprintf("sidebar %s should hug the left or right edge", name)
 			}
-		case strings.Contains(id, "foot"):
-			if yMid < 0.9*maxY {
-				return fmt.Errorf("🧭 footer (%s) should be at the bottom", c.ID)
+		case taxonomy.FAB:
+			if xMid < rule.FabMin*maxX || yMid < rule.FabMin*maxY {
+				problem = fmt.Sprintf("floating action button %s should be in the bottom-right corner", name)
 			}
 		}
+
+		if problem != "" {
+			diags = append(diags, newDiagnostic(RuleSemanticZone, rule.Severity, problem, c))
+		}
+	}
+
+	if len(diags) == 0 {
+		debugLog("✅ Semantic zone checks passed\n")
 	}
+	return diags
+}
 
-	debugLog("✅ Semantic zone checks passed")
-	return nil
+// describe renders a cell as `"Label" (id)` for messages, or just the id.
+func describe(c mxCell) string {
+	if c.Value == "" {
+		return fmt.Sprintf("(%s)", c.ID)
+	}
+	return fmt.Sprintf("%q (%s)", c.Value, c.ID)
 }
@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// page wraps top-level vertices, given as "label:x,y,w,h" with the label as
+// their ID, in an mxGraphModel with the given attributes.
+func page(attrs string, cells ...string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<mxGraphModel%s><root><mxCell id="0"/><mxCell id="1" parent="0"/>`, attrs)
+	for _, c := range cells {
+		label, geo, _ := strings.Cut(c, ":")
+		var x, y, w, h float64
+		fmt.Sscanf(geo, "%g,%g,%g,%g", &x, &y, &w, &h)
+		fmt.Fprintf(&b, `<mxCell id="%s" value="%s" vertex="1" parent="1"><mxGeometry x="%g" y="%g" width="%g" height="%g" as="geometry"/></mxCell>`,
+			label, label, x, y, w, h)
+	}
+	b.WriteString(`</root></mxGraphModel>`)
+	return b.String()
+}
+
+func TestSemanticZones(t *testing.T) {
+	const a4 = ` pageWidth="800" pageHeight="1100"`
+	tests := []struct {
+		name string
+		xml  string
+		want []string // IDs of the misplaced landmarks
+	}{
+		{
+			name: "tall navbar at the top of a short layout",
+			xml:  page("", "Navbar:0,0,800,60", "Content:0,80,800,70"),
+		},
+		{
+			name: "navbar halfway down a declared page",
+			xml:  page(a4, "Content:0,0,800,300", "Navbar:0,400,800,60"),
+			want: []string{"Navbar"},
+		},
+		{
+			name: "tall footer at the bottom of a short layout",
+			xml:  page("", "Content:0,0,800,100", "Footer:0,100,800,50"),
+		},
+		{
+			name: "footer below short content on a declared page",
+			xml:  page(a4, "Content:0,0,800,100", "Footer:0,100,800,50"),
+			want: []string{"Footer"},
+		},
+		{
+			name: "footer at the bottom of a declared page",
+			xml:  page(a4, "Content:0,0,800,100", "Footer:0,1040,800,60"),
+		},
+		{
+			name: "modal centered on a declared page, not on the content",
+			xml:  page(a4, "Content:0,0,800,700", "Modal:200,400,400,300"),
+		},
+		{
+			name: "content past the declared page grows it",
+			xml:  page(a4, "Content:0,0,800,2000", "Footer:0,2000,800,60"),
+		},
+	}
+	rules := Rules{SemanticZone: DefaultRules().SemanticZone}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags, err := ValidateWith(tt.xml, rules)
+			if err != nil {
+				t.Fatalf("ValidateWith: %v", err)
+			}
+			var got []string
+			for _, d := range diags {
+				got = append(got, d.CellIDs...)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("misplaced = %v, want %v: %v", got, tt.want, diags)
+			}
+		})
+	}
+}